@@ -10,6 +10,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	_ "google.golang.org/protobuf/types/gofeaturespb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -21,6 +23,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WatchEventType int32
+
+const (
+	WatchEventType_RESULT     WatchEventType = 0
+	WatchEventType_REGISTER   WatchEventType = 1
+	WatchEventType_UPDATE     WatchEventType = 2
+	WatchEventType_DISCONNECT WatchEventType = 3
+	WatchEventType_REAPED     WatchEventType = 4
+	WatchEventType_REMOVED    WatchEventType = 5
+)
+
+// Enum value maps for WatchEventType.
+var (
+	WatchEventType_name = map[int32]string{
+		0: "RESULT",
+		1: "REGISTER",
+		2: "UPDATE",
+		3: "DISCONNECT",
+		4: "REAPED",
+		5: "REMOVED",
+	}
+	WatchEventType_value = map[string]int32{
+		"RESULT":     0,
+		"REGISTER":   1,
+		"UPDATE":     2,
+		"DISCONNECT": 3,
+		"REAPED":     4,
+		"REMOVED":    5,
+	}
+)
+
+func (x WatchEventType) Enum() *WatchEventType {
+	p := new(WatchEventType)
+	*p = x
+	return p
+}
+
+func (x WatchEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_na4ma4_rsca_api_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchEventType) Type() protoreflect.EnumType {
+	return &file_github_com_na4ma4_rsca_api_admin_proto_enumTypes[0]
+}
+
+func (x WatchEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type RemoveHostRequest struct {
 	state            protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Names []string               `protobuf:"bytes,1,rep,name=names"`
@@ -135,47 +190,1662 @@ func (b0 RemoveHostResponse_builder) Build() *RemoveHostResponse {
 	return m0
 }
 
-var File_github_com_na4ma4_rsca_api_admin_proto protoreflect.FileDescriptor
+type TriggerCheckRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Members *Members               `protobuf:"bytes,1,opt,name=members"`
+	xxx_hidden_Checks  []string               `protobuf:"bytes,2,rep,name=checks"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
 
-const file_github_com_na4ma4_rsca_api_admin_proto_rawDesc = "" +
-	"\n" +
-	"&github.com/na4ma4/rsca/api/admin.proto\x12\brsca.api\x1a!google/protobuf/go_features.proto\x1a'github.com/na4ma4/rsca/api/common.proto\")\n" +
-	"\x11RemoveHostRequest\x12\x14\n" +
-	"\x05names\x18\x01 \x03(\tR\x05names\"*\n" +
-	"\x12RemoveHostResponse\x12\x14\n" +
-	"\x05names\x18\x01 \x03(\tR\x05names2\x82\x02\n" +
+func (x *TriggerCheckRequest) Reset() {
+	*x = TriggerCheckRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerCheckRequest) ProtoMessage() {}
+
+func (x *TriggerCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TriggerCheckRequest) GetMembers() *Members {
+	if x != nil {
+		return x.xxx_hidden_Members
+	}
+	return nil
+}
+
+func (x *TriggerCheckRequest) GetChecks() []string {
+	if x != nil {
+		return x.xxx_hidden_Checks
+	}
+	return nil
+}
+
+func (x *TriggerCheckRequest) SetMembers(v *Members) {
+	x.xxx_hidden_Members = v
+}
+
+func (x *TriggerCheckRequest) SetChecks(v []string) {
+	x.xxx_hidden_Checks = v
+}
+
+func (x *TriggerCheckRequest) HasMembers() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Members != nil
+}
+
+func (x *TriggerCheckRequest) ClearMembers() {
+	x.xxx_hidden_Members = nil
+}
+
+type TriggerCheckRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Members *Members
+	Checks  []string
+}
+
+func (b0 TriggerCheckRequest_builder) Build() *TriggerCheckRequest {
+	m0 := &TriggerCheckRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Members = b.Members
+	x.xxx_hidden_Checks = b.Checks
+	return m0
+}
+
+type TriggerCheckResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Checks *[]*TriggeredCheck     `protobuf:"bytes,1,rep,name=checks"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TriggerCheckResponse) Reset() {
+	*x = TriggerCheckResponse{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerCheckResponse) ProtoMessage() {}
+
+func (x *TriggerCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TriggerCheckResponse) GetChecks() []*TriggeredCheck {
+	if x != nil {
+		if x.xxx_hidden_Checks != nil {
+			return *x.xxx_hidden_Checks
+		}
+	}
+	return nil
+}
+
+func (x *TriggerCheckResponse) SetChecks(v []*TriggeredCheck) {
+	x.xxx_hidden_Checks = &v
+}
+
+type TriggerCheckResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Checks []*TriggeredCheck
+}
+
+func (b0 TriggerCheckResponse_builder) Build() *TriggerCheckResponse {
+	m0 := &TriggerCheckResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Checks = &b.Checks
+	return m0
+}
+
+// TriggeredCheck is a check that was scheduled to run on a host.
+type TriggeredCheck struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostname    *string                `protobuf:"bytes,1,opt,name=hostname"`
+	xxx_hidden_Check       *string                `protobuf:"bytes,2,opt,name=check"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TriggeredCheck) Reset() {
+	*x = TriggeredCheck{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggeredCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggeredCheck) ProtoMessage() {}
+
+func (x *TriggeredCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TriggeredCheck) GetHostname() string {
+	if x != nil {
+		if x.xxx_hidden_Hostname != nil {
+			return *x.xxx_hidden_Hostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TriggeredCheck) GetCheck() string {
+	if x != nil {
+		if x.xxx_hidden_Check != nil {
+			return *x.xxx_hidden_Check
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TriggeredCheck) SetHostname(v string) {
+	x.xxx_hidden_Hostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *TriggeredCheck) SetCheck(v string) {
+	x.xxx_hidden_Check = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *TriggeredCheck) HasHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TriggeredCheck) HasCheck() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TriggeredCheck) ClearHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Hostname = nil
+}
+
+func (x *TriggeredCheck) ClearCheck() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Check = nil
+}
+
+type TriggeredCheck_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hostname *string
+	Check    *string
+}
+
+func (b0 TriggeredCheck_builder) Build() *TriggeredCheck {
+	m0 := &TriggeredCheck{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Hostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Hostname = b.Hostname
+	}
+	if b.Check != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Check = b.Check
+	}
+	return m0
+}
+
+type RunCheckRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostname    *string                `protobuf:"bytes,1,opt,name=hostname"`
+	xxx_hidden_Check       *string                `protobuf:"bytes,2,opt,name=check"`
+	xxx_hidden_Forward     bool                   `protobuf:"varint,3,opt,name=forward"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RunCheckRequest) Reset() {
+	*x = RunCheckRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunCheckRequest) ProtoMessage() {}
+
+func (x *RunCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RunCheckRequest) GetHostname() string {
+	if x != nil {
+		if x.xxx_hidden_Hostname != nil {
+			return *x.xxx_hidden_Hostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RunCheckRequest) GetCheck() string {
+	if x != nil {
+		if x.xxx_hidden_Check != nil {
+			return *x.xxx_hidden_Check
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RunCheckRequest) GetForward() bool {
+	if x != nil {
+		return x.xxx_hidden_Forward
+	}
+	return false
+}
+
+func (x *RunCheckRequest) SetHostname(v string) {
+	x.xxx_hidden_Hostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *RunCheckRequest) SetCheck(v string) {
+	x.xxx_hidden_Check = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *RunCheckRequest) SetForward(v bool) {
+	x.xxx_hidden_Forward = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *RunCheckRequest) HasHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RunCheckRequest) HasCheck() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RunCheckRequest) HasForward() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RunCheckRequest) ClearHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Hostname = nil
+}
+
+func (x *RunCheckRequest) ClearCheck() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Check = nil
+}
+
+func (x *RunCheckRequest) ClearForward() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Forward = false
+}
+
+type RunCheckRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hostname *string
+	Check    *string
+	// Forward the result to nagios as well as returning it.
+	Forward *bool
+}
+
+func (b0 RunCheckRequest_builder) Build() *RunCheckRequest {
+	m0 := &RunCheckRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Hostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Hostname = b.Hostname
+	}
+	if b.Check != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Check = b.Check
+	}
+	if b.Forward != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Forward = *b.Forward
+	}
+	return m0
+}
+
+type RunCheckResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Event *EventMessage          `protobuf:"bytes,1,opt,name=event"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RunCheckResponse) Reset() {
+	*x = RunCheckResponse{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunCheckResponse) ProtoMessage() {}
+
+func (x *RunCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RunCheckResponse) GetEvent() *EventMessage {
+	if x != nil {
+		return x.xxx_hidden_Event
+	}
+	return nil
+}
+
+func (x *RunCheckResponse) SetEvent(v *EventMessage) {
+	x.xxx_hidden_Event = v
+}
+
+func (x *RunCheckResponse) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *RunCheckResponse) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+type RunCheckResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Event *EventMessage
+}
+
+func (b0 RunCheckResponse_builder) Build() *RunCheckResponse {
+	m0 := &RunCheckResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Event = b.Event
+	return m0
+}
+
+// ListResultsRequest filters the latest check results, empty fields match all results.
+type ListResultsRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostnames []string               `protobuf:"bytes,1,rep,name=hostnames"`
+	xxx_hidden_Checks    []string               `protobuf:"bytes,2,rep,name=checks"`
+	xxx_hidden_Statuses  []Status               `protobuf:"varint,3,rep,packed,name=statuses,enum=rsca.api.Status"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListResultsRequest) Reset() {
+	*x = ListResultsRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResultsRequest) ProtoMessage() {}
+
+func (x *ListResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListResultsRequest) GetHostnames() []string {
+	if x != nil {
+		return x.xxx_hidden_Hostnames
+	}
+	return nil
+}
+
+func (x *ListResultsRequest) GetChecks() []string {
+	if x != nil {
+		return x.xxx_hidden_Checks
+	}
+	return nil
+}
+
+func (x *ListResultsRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.xxx_hidden_Statuses
+	}
+	return nil
+}
+
+func (x *ListResultsRequest) SetHostnames(v []string) {
+	x.xxx_hidden_Hostnames = v
+}
+
+func (x *ListResultsRequest) SetChecks(v []string) {
+	x.xxx_hidden_Checks = v
+}
+
+func (x *ListResultsRequest) SetStatuses(v []Status) {
+	x.xxx_hidden_Statuses = v
+}
+
+type ListResultsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hostnames []string
+	Checks    []string
+	Statuses  []Status
+}
+
+func (b0 ListResultsRequest_builder) Build() *ListResultsRequest {
+	m0 := &ListResultsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Hostnames = b.Hostnames
+	x.xxx_hidden_Checks = b.Checks
+	x.xxx_hidden_Statuses = b.Statuses
+	return m0
+}
+
+// ResultHistoryRequest selects the stored check results of a host and check, unset times are unbounded.
+type ResultHistoryRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostname    *string                `protobuf:"bytes,1,opt,name=hostname"`
+	xxx_hidden_Check       *string                `protobuf:"bytes,2,opt,name=check"`
+	xxx_hidden_Since       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since"`
+	xxx_hidden_Until       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResultHistoryRequest) Reset() {
+	*x = ResultHistoryRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultHistoryRequest) ProtoMessage() {}
+
+func (x *ResultHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResultHistoryRequest) GetHostname() string {
+	if x != nil {
+		if x.xxx_hidden_Hostname != nil {
+			return *x.xxx_hidden_Hostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResultHistoryRequest) GetCheck() string {
+	if x != nil {
+		if x.xxx_hidden_Check != nil {
+			return *x.xxx_hidden_Check
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResultHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Since
+	}
+	return nil
+}
+
+func (x *ResultHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Until
+	}
+	return nil
+}
+
+func (x *ResultHistoryRequest) SetHostname(v string) {
+	x.xxx_hidden_Hostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ResultHistoryRequest) SetCheck(v string) {
+	x.xxx_hidden_Check = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ResultHistoryRequest) SetSince(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Since = v
+}
+
+func (x *ResultHistoryRequest) SetUntil(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Until = v
+}
+
+func (x *ResultHistoryRequest) HasHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResultHistoryRequest) HasCheck() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ResultHistoryRequest) HasSince() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Since != nil
+}
+
+func (x *ResultHistoryRequest) HasUntil() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Until != nil
+}
+
+func (x *ResultHistoryRequest) ClearHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Hostname = nil
+}
+
+func (x *ResultHistoryRequest) ClearCheck() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Check = nil
+}
+
+func (x *ResultHistoryRequest) ClearSince() {
+	x.xxx_hidden_Since = nil
+}
+
+func (x *ResultHistoryRequest) ClearUntil() {
+	x.xxx_hidden_Until = nil
+}
+
+type ResultHistoryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hostname *string
+	Check    *string
+	Since    *timestamppb.Timestamp
+	Until    *timestamppb.Timestamp
+}
+
+func (b0 ResultHistoryRequest_builder) Build() *ResultHistoryRequest {
+	m0 := &ResultHistoryRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Hostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Hostname = b.Hostname
+	}
+	if b.Check != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Check = b.Check
+	}
+	x.xxx_hidden_Since = b.Since
+	x.xxx_hidden_Until = b.Until
+	return m0
+}
+
+type ResultHistoryResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Events *[]*EventMessage       `protobuf:"bytes,1,rep,name=events"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ResultHistoryResponse) Reset() {
+	*x = ResultHistoryResponse{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultHistoryResponse) ProtoMessage() {}
+
+func (x *ResultHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResultHistoryResponse) GetEvents() []*EventMessage {
+	if x != nil {
+		if x.xxx_hidden_Events != nil {
+			return *x.xxx_hidden_Events
+		}
+	}
+	return nil
+}
+
+func (x *ResultHistoryResponse) SetEvents(v []*EventMessage) {
+	x.xxx_hidden_Events = &v
+}
+
+type ResultHistoryResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Events []*EventMessage
+}
+
+func (b0 ResultHistoryResponse_builder) Build() *ResultHistoryResponse {
+	m0 := &ResultHistoryResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Events = &b.Events
+	return m0
+}
+
+// WatchRequest filters the live events, empty fields match all events. The check and status
+// filters only match check results.
+type WatchRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostnames []string               `protobuf:"bytes,1,rep,name=hostnames"`
+	xxx_hidden_Tags      []string               `protobuf:"bytes,2,rep,name=tags"`
+	xxx_hidden_Checks    []string               `protobuf:"bytes,3,rep,name=checks"`
+	xxx_hidden_Statuses  []Status               `protobuf:"varint,4,rep,packed,name=statuses,enum=rsca.api.Status"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchRequest) GetHostnames() []string {
+	if x != nil {
+		return x.xxx_hidden_Hostnames
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetChecks() []string {
+	if x != nil {
+		return x.xxx_hidden_Checks
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.xxx_hidden_Statuses
+	}
+	return nil
+}
+
+func (x *WatchRequest) SetHostnames(v []string) {
+	x.xxx_hidden_Hostnames = v
+}
+
+func (x *WatchRequest) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *WatchRequest) SetChecks(v []string) {
+	x.xxx_hidden_Checks = v
+}
+
+func (x *WatchRequest) SetStatuses(v []Status) {
+	x.xxx_hidden_Statuses = v
+}
+
+type WatchRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Hostnames []string
+	Tags      []string
+	Checks    []string
+	Statuses  []Status
+}
+
+func (b0 WatchRequest_builder) Build() *WatchRequest {
+	m0 := &WatchRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Hostnames = b.Hostnames
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Checks = b.Checks
+	x.xxx_hidden_Statuses = b.Statuses
+	return m0
+}
+
+// WatchEvent is a check result or host lifecycle event.
+type WatchEvent struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type        WatchEventType         `protobuf:"varint,1,opt,name=type,enum=rsca.api.WatchEventType"`
+	xxx_hidden_Hostname    *string                `protobuf:"bytes,2,opt,name=hostname"`
+	xxx_hidden_Timestamp   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp"`
+	xxx_hidden_Event       *EventMessage          `protobuf:"bytes,4,opt,name=event"`
+	xxx_hidden_Member      *Member                `protobuf:"bytes,5,opt,name=member"`
+	xxx_hidden_Dropped     uint64                 `protobuf:"varint,6,opt,name=dropped"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchEvent) GetType() WatchEventType {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return WatchEventType_RESULT
+}
+
+func (x *WatchEvent) GetHostname() string {
+	if x != nil {
+		if x.xxx_hidden_Hostname != nil {
+			return *x.xxx_hidden_Hostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Timestamp
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetEvent() *EventMessage {
+	if x != nil {
+		return x.xxx_hidden_Event
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetMember() *Member {
+	if x != nil {
+		return x.xxx_hidden_Member
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetDropped() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Dropped
+	}
+	return 0
+}
+
+func (x *WatchEvent) SetType(v WatchEventType) {
+	x.xxx_hidden_Type = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *WatchEvent) SetHostname(v string) {
+	x.xxx_hidden_Hostname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *WatchEvent) SetTimestamp(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Timestamp = v
+}
+
+func (x *WatchEvent) SetEvent(v *EventMessage) {
+	x.xxx_hidden_Event = v
+}
+
+func (x *WatchEvent) SetMember(v *Member) {
+	x.xxx_hidden_Member = v
+}
+
+func (x *WatchEvent) SetDropped(v uint64) {
+	x.xxx_hidden_Dropped = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *WatchEvent) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WatchEvent) HasHostname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WatchEvent) HasTimestamp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timestamp != nil
+}
+
+func (x *WatchEvent) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *WatchEvent) HasMember() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Member != nil
+}
+
+func (x *WatchEvent) HasDropped() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WatchEvent) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = WatchEventType_RESULT
+}
+
+func (x *WatchEvent) ClearHostname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Hostname = nil
+}
+
+func (x *WatchEvent) ClearTimestamp() {
+	x.xxx_hidden_Timestamp = nil
+}
+
+func (x *WatchEvent) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+func (x *WatchEvent) ClearMember() {
+	x.xxx_hidden_Member = nil
+}
+
+func (x *WatchEvent) ClearDropped() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Dropped = 0
+}
+
+type WatchEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Type      *WatchEventType
+	Hostname  *string
+	Timestamp *timestamppb.Timestamp
+	// Check result of a RESULT event.
+	Event *EventMessage
+	// Host of a lifecycle event, when it is known.
+	Member *Member
+	// Number of events that were not sent to the subscriber before this event because it was not
+	// keeping up.
+	Dropped *uint64
+}
+
+func (b0 WatchEvent_builder) Build() *WatchEvent {
+	m0 := &WatchEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Type = *b.Type
+	}
+	if b.Hostname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Hostname = b.Hostname
+	}
+	x.xxx_hidden_Timestamp = b.Timestamp
+	x.xxx_hidden_Event = b.Event
+	x.xxx_hidden_Member = b.Member
+	if b.Dropped != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Dropped = *b.Dropped
+	}
+	return m0
+}
+
+// AuditEntry is a record of an administrative request.
+type AuditEntry struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp"`
+	xxx_hidden_Method        *string                `protobuf:"bytes,2,opt,name=method"`
+	xxx_hidden_Identity      []string               `protobuf:"bytes,3,rep,name=identity"`
+	xxx_hidden_Fingerprint   *string                `protobuf:"bytes,4,opt,name=fingerprint"`
+	xxx_hidden_PeerAddress   *string                `protobuf:"bytes,5,opt,name=peer_address,json=peerAddress"`
+	xxx_hidden_Targets       []string               `protobuf:"bytes,6,rep,name=targets"`
+	xxx_hidden_AffectedHosts []string               `protobuf:"bytes,7,rep,name=affected_hosts,json=affectedHosts"`
+	xxx_hidden_Outcome       *string                `protobuf:"bytes,8,opt,name=outcome"`
+	xxx_hidden_Error         *string                `protobuf:"bytes,9,opt,name=error"`
+	xxx_hidden_Duration      *durationpb.Duration   `protobuf:"bytes,10,opt,name=duration"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AuditEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Timestamp
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetIdentity() []string {
+	if x != nil {
+		return x.xxx_hidden_Identity
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetFingerprint() string {
+	if x != nil {
+		if x.xxx_hidden_Fingerprint != nil {
+			return *x.xxx_hidden_Fingerprint
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPeerAddress() string {
+	if x != nil {
+		if x.xxx_hidden_PeerAddress != nil {
+			return *x.xxx_hidden_PeerAddress
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTargets() []string {
+	if x != nil {
+		return x.xxx_hidden_Targets
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetAffectedHosts() []string {
+	if x != nil {
+		return x.xxx_hidden_AffectedHosts
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetOutcome() string {
+	if x != nil {
+		if x.xxx_hidden_Outcome != nil {
+			return *x.xxx_hidden_Outcome
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Duration
+	}
+	return nil
+}
+
+func (x *AuditEntry) SetTimestamp(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Timestamp = v
+}
+
+func (x *AuditEntry) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *AuditEntry) SetIdentity(v []string) {
+	x.xxx_hidden_Identity = v
+}
+
+func (x *AuditEntry) SetFingerprint(v string) {
+	x.xxx_hidden_Fingerprint = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *AuditEntry) SetPeerAddress(v string) {
+	x.xxx_hidden_PeerAddress = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *AuditEntry) SetTargets(v []string) {
+	x.xxx_hidden_Targets = v
+}
+
+func (x *AuditEntry) SetAffectedHosts(v []string) {
+	x.xxx_hidden_AffectedHosts = v
+}
+
+func (x *AuditEntry) SetOutcome(v string) {
+	x.xxx_hidden_Outcome = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *AuditEntry) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *AuditEntry) SetDuration(v *durationpb.Duration) {
+	x.xxx_hidden_Duration = v
+}
+
+func (x *AuditEntry) HasTimestamp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timestamp != nil
+}
+
+func (x *AuditEntry) HasMethod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AuditEntry) HasFingerprint() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *AuditEntry) HasPeerAddress() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *AuditEntry) HasOutcome() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *AuditEntry) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *AuditEntry) HasDuration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Duration != nil
+}
+
+func (x *AuditEntry) ClearTimestamp() {
+	x.xxx_hidden_Timestamp = nil
+}
+
+func (x *AuditEntry) ClearMethod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Method = nil
+}
+
+func (x *AuditEntry) ClearFingerprint() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Fingerprint = nil
+}
+
+func (x *AuditEntry) ClearPeerAddress() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_PeerAddress = nil
+}
+
+func (x *AuditEntry) ClearOutcome() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Outcome = nil
+}
+
+func (x *AuditEntry) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Error = nil
+}
+
+func (x *AuditEntry) ClearDuration() {
+	x.xxx_hidden_Duration = nil
+}
+
+type AuditEntry_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Timestamp *timestamppb.Timestamp
+	Method    *string
+	// Names of the client certificate.
+	Identity    []string
+	Fingerprint *string
+	PeerAddress *string
+	// Hosts and checks that were requested.
+	Targets []string
+	// Hosts that the request was applied to.
+	AffectedHosts []string
+	// gRPC status code of the request (eg. OK, PermissionDenied).
+	Outcome  *string
+	Error    *string
+	Duration *durationpb.Duration
+}
+
+func (b0 AuditEntry_builder) Build() *AuditEntry {
+	m0 := &AuditEntry{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Timestamp = b.Timestamp
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Method = b.Method
+	}
+	x.xxx_hidden_Identity = b.Identity
+	if b.Fingerprint != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_Fingerprint = b.Fingerprint
+	}
+	if b.PeerAddress != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_PeerAddress = b.PeerAddress
+	}
+	x.xxx_hidden_Targets = b.Targets
+	x.xxx_hidden_AffectedHosts = b.AffectedHosts
+	if b.Outcome != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_Outcome = b.Outcome
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_Error = b.Error
+	}
+	x.xxx_hidden_Duration = b.Duration
+	return m0
+}
+
+// ListAuditRequest selects the most recent audit entries, unset fields are unbounded.
+type ListAuditRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Since       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,2,opt,name=limit"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListAuditRequest) Reset() {
+	*x = ListAuditRequest{}
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditRequest) ProtoMessage() {}
+
+func (x *ListAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAuditRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Since
+	}
+	return nil
+}
+
+func (x *ListAuditRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *ListAuditRequest) SetSince(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Since = v
+}
+
+func (x *ListAuditRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListAuditRequest) HasSince() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Since != nil
+}
+
+func (x *ListAuditRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListAuditRequest) ClearSince() {
+	x.xxx_hidden_Since = nil
+}
+
+func (x *ListAuditRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Limit = 0
+}
+
+type ListAuditRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Since *timestamppb.Timestamp
+	Limit *int32
+}
+
+func (b0 ListAuditRequest_builder) Build() *ListAuditRequest {
+	m0 := &ListAuditRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Since = b.Since
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	return m0
+}
+
+var File_github_com_na4ma4_rsca_api_admin_proto protoreflect.FileDescriptor
+
+const file_github_com_na4ma4_rsca_api_admin_proto_rawDesc = "" +
+	"\n" +
+	"&github.com/na4ma4/rsca/api/admin.proto\x12\brsca.api\x1a!google/protobuf/go_features.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a'github.com/na4ma4/rsca/api/common.proto\")\n" +
+	"\x11RemoveHostRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"*\n" +
+	"\x12RemoveHostResponse\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"Z\n" +
+	"\x13TriggerCheckRequest\x12+\n" +
+	"\amembers\x18\x01 \x01(\v2\x11.rsca.api.MembersR\amembers\x12\x16\n" +
+	"\x06checks\x18\x02 \x03(\tR\x06checks\"H\n" +
+	"\x14TriggerCheckResponse\x120\n" +
+	"\x06checks\x18\x01 \x03(\v2\x18.rsca.api.TriggeredCheckR\x06checks\"B\n" +
+	"\x0eTriggeredCheck\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\"]\n" +
+	"\x0fRunCheckRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\x12\x18\n" +
+	"\aforward\x18\x03 \x01(\bR\aforward\"@\n" +
+	"\x10RunCheckResponse\x12,\n" +
+	"\x05event\x18\x01 \x01(\v2\x16.rsca.api.EventMessageR\x05event\"x\n" +
+	"\x12ListResultsRequest\x12\x1c\n" +
+	"\thostnames\x18\x01 \x03(\tR\thostnames\x12\x16\n" +
+	"\x06checks\x18\x02 \x03(\tR\x06checks\x12,\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x10.rsca.api.StatusR\bstatuses\"\xac\x01\n" +
+	"\x14ResultHistoryRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"G\n" +
+	"\x15ResultHistoryResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.rsca.api.EventMessageR\x06events\"\x86\x01\n" +
+	"\fWatchRequest\x12\x1c\n" +
+	"\thostnames\x18\x01 \x03(\tR\thostnames\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06checks\x18\x03 \x03(\tR\x06checks\x12,\n" +
+	"\bstatuses\x18\x04 \x03(\x0e2\x10.rsca.api.StatusR\bstatuses\"\x82\x02\n" +
+	"\n" +
+	"WatchEvent\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.rsca.api.WatchEventTypeR\x04type\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12,\n" +
+	"\x05event\x18\x04 \x01(\v2\x16.rsca.api.EventMessageR\x05event\x12(\n" +
+	"\x06member\x18\x05 \x01(\v2\x10.rsca.api.MemberR\x06member\x12\x18\n" +
+	"\adropped\x18\x06 \x01(\x04R\adropped\"\xe7\x02\n" +
+	"\n" +
+	"AuditEntry\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x1a\n" +
+	"\bidentity\x18\x03 \x03(\tR\bidentity\x12 \n" +
+	"\vfingerprint\x18\x04 \x01(\tR\vfingerprint\x12!\n" +
+	"\fpeer_address\x18\x05 \x01(\tR\vpeerAddress\x12\x18\n" +
+	"\atargets\x18\x06 \x03(\tR\atargets\x12%\n" +
+	"\x0eaffected_hosts\x18\a \x03(\tR\raffectedHosts\x12\x18\n" +
+	"\aoutcome\x18\b \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x125\n" +
+	"\bduration\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\bduration\"Z\n" +
+	"\x10ListAuditRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit*_\n" +
+	"\x0eWatchEventType\x12\n" +
+	"\n" +
+	"\x06RESULT\x10\x00\x12\f\n" +
+	"\bREGISTER\x10\x01\x12\n" +
+	"\n" +
+	"\x06UPDATE\x10\x02\x12\x0e\n" +
+	"\n" +
+	"DISCONNECT\x10\x03\x12\n" +
+	"\n" +
+	"\x06REAPED\x10\x04\x12\v\n" +
+	"\aREMOVED\x10\x052\xaa\x05\n" +
 	"\x05Admin\x120\n" +
 	"\tListHosts\x12\x0f.rsca.api.Empty\x1a\x10.rsca.api.Member0\x01\x12G\n" +
 	"\n" +
 	"RemoveHost\x12\x1b.rsca.api.RemoveHostRequest\x1a\x1c.rsca.api.RemoveHostResponse\x12=\n" +
 	"\n" +
 	"TriggerAll\x12\x11.rsca.api.Members\x1a\x1c.rsca.api.TriggerAllResponse\x12?\n" +
-	"\vTriggerInfo\x12\x11.rsca.api.Members\x1a\x1d.rsca.api.TriggerInfoResponseB$Z\x1agithub.com/na4ma4/rsca/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
+	"\vTriggerInfo\x12\x11.rsca.api.Members\x1a\x1d.rsca.api.TriggerInfoResponse\x12M\n" +
+	"\fTriggerCheck\x12\x1d.rsca.api.TriggerCheckRequest\x1a\x1e.rsca.api.TriggerCheckResponse\x12A\n" +
+	"\bRunCheck\x12\x19.rsca.api.RunCheckRequest\x1a\x1a.rsca.api.RunCheckResponse\x12E\n" +
+	"\vListResults\x12\x1c.rsca.api.ListResultsRequest\x1a\x16.rsca.api.EventMessage0\x01\x12S\n" +
+	"\x10GetResultHistory\x12\x1e.rsca.api.ResultHistoryRequest\x1a\x1f.rsca.api.ResultHistoryResponse\x127\n" +
+	"\x05Watch\x12\x16.rsca.api.WatchRequest\x1a\x14.rsca.api.WatchEvent0\x01\x12?\n" +
+	"\tListAudit\x12\x1a.rsca.api.ListAuditRequest\x1a\x14.rsca.api.AuditEntry0\x01B$Z\x1agithub.com/na4ma4/rsca/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
-var file_github_com_na4ma4_rsca_api_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_na4ma4_rsca_api_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_na4ma4_rsca_api_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_github_com_na4ma4_rsca_api_admin_proto_goTypes = []any{
-	(*RemoveHostRequest)(nil),   // 0: rsca.api.RemoveHostRequest
-	(*RemoveHostResponse)(nil),  // 1: rsca.api.RemoveHostResponse
-	(*Empty)(nil),               // 2: rsca.api.Empty
-	(*Members)(nil),             // 3: rsca.api.Members
-	(*Member)(nil),              // 4: rsca.api.Member
-	(*TriggerAllResponse)(nil),  // 5: rsca.api.TriggerAllResponse
-	(*TriggerInfoResponse)(nil), // 6: rsca.api.TriggerInfoResponse
+	(WatchEventType)(0),           // 0: rsca.api.WatchEventType
+	(*RemoveHostRequest)(nil),     // 1: rsca.api.RemoveHostRequest
+	(*RemoveHostResponse)(nil),    // 2: rsca.api.RemoveHostResponse
+	(*TriggerCheckRequest)(nil),   // 3: rsca.api.TriggerCheckRequest
+	(*TriggerCheckResponse)(nil),  // 4: rsca.api.TriggerCheckResponse
+	(*TriggeredCheck)(nil),        // 5: rsca.api.TriggeredCheck
+	(*RunCheckRequest)(nil),       // 6: rsca.api.RunCheckRequest
+	(*RunCheckResponse)(nil),      // 7: rsca.api.RunCheckResponse
+	(*ListResultsRequest)(nil),    // 8: rsca.api.ListResultsRequest
+	(*ResultHistoryRequest)(nil),  // 9: rsca.api.ResultHistoryRequest
+	(*ResultHistoryResponse)(nil), // 10: rsca.api.ResultHistoryResponse
+	(*WatchRequest)(nil),          // 11: rsca.api.WatchRequest
+	(*WatchEvent)(nil),            // 12: rsca.api.WatchEvent
+	(*AuditEntry)(nil),            // 13: rsca.api.AuditEntry
+	(*ListAuditRequest)(nil),      // 14: rsca.api.ListAuditRequest
+	(*Members)(nil),               // 15: rsca.api.Members
+	(*EventMessage)(nil),          // 16: rsca.api.EventMessage
+	(Status)(0),                   // 17: rsca.api.Status
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*Member)(nil),                // 19: rsca.api.Member
+	(*durationpb.Duration)(nil),   // 20: google.protobuf.Duration
+	(*Empty)(nil),                 // 21: rsca.api.Empty
+	(*TriggerAllResponse)(nil),    // 22: rsca.api.TriggerAllResponse
+	(*TriggerInfoResponse)(nil),   // 23: rsca.api.TriggerInfoResponse
 }
 var file_github_com_na4ma4_rsca_api_admin_proto_depIdxs = []int32{
-	2, // 0: rsca.api.Admin.ListHosts:input_type -> rsca.api.Empty
-	0, // 1: rsca.api.Admin.RemoveHost:input_type -> rsca.api.RemoveHostRequest
-	3, // 2: rsca.api.Admin.TriggerAll:input_type -> rsca.api.Members
-	3, // 3: rsca.api.Admin.TriggerInfo:input_type -> rsca.api.Members
-	4, // 4: rsca.api.Admin.ListHosts:output_type -> rsca.api.Member
-	1, // 5: rsca.api.Admin.RemoveHost:output_type -> rsca.api.RemoveHostResponse
-	5, // 6: rsca.api.Admin.TriggerAll:output_type -> rsca.api.TriggerAllResponse
-	6, // 7: rsca.api.Admin.TriggerInfo:output_type -> rsca.api.TriggerInfoResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	15, // 0: rsca.api.TriggerCheckRequest.members:type_name -> rsca.api.Members
+	5,  // 1: rsca.api.TriggerCheckResponse.checks:type_name -> rsca.api.TriggeredCheck
+	16, // 2: rsca.api.RunCheckResponse.event:type_name -> rsca.api.EventMessage
+	17, // 3: rsca.api.ListResultsRequest.statuses:type_name -> rsca.api.Status
+	18, // 4: rsca.api.ResultHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	18, // 5: rsca.api.ResultHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	16, // 6: rsca.api.ResultHistoryResponse.events:type_name -> rsca.api.EventMessage
+	17, // 7: rsca.api.WatchRequest.statuses:type_name -> rsca.api.Status
+	0,  // 8: rsca.api.WatchEvent.type:type_name -> rsca.api.WatchEventType
+	18, // 9: rsca.api.WatchEvent.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 10: rsca.api.WatchEvent.event:type_name -> rsca.api.EventMessage
+	19, // 11: rsca.api.WatchEvent.member:type_name -> rsca.api.Member
+	18, // 12: rsca.api.AuditEntry.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 13: rsca.api.AuditEntry.duration:type_name -> google.protobuf.Duration
+	18, // 14: rsca.api.ListAuditRequest.since:type_name -> google.protobuf.Timestamp
+	21, // 15: rsca.api.Admin.ListHosts:input_type -> rsca.api.Empty
+	1,  // 16: rsca.api.Admin.RemoveHost:input_type -> rsca.api.RemoveHostRequest
+	15, // 17: rsca.api.Admin.TriggerAll:input_type -> rsca.api.Members
+	15, // 18: rsca.api.Admin.TriggerInfo:input_type -> rsca.api.Members
+	3,  // 19: rsca.api.Admin.TriggerCheck:input_type -> rsca.api.TriggerCheckRequest
+	6,  // 20: rsca.api.Admin.RunCheck:input_type -> rsca.api.RunCheckRequest
+	8,  // 21: rsca.api.Admin.ListResults:input_type -> rsca.api.ListResultsRequest
+	9,  // 22: rsca.api.Admin.GetResultHistory:input_type -> rsca.api.ResultHistoryRequest
+	11, // 23: rsca.api.Admin.Watch:input_type -> rsca.api.WatchRequest
+	14, // 24: rsca.api.Admin.ListAudit:input_type -> rsca.api.ListAuditRequest
+	19, // 25: rsca.api.Admin.ListHosts:output_type -> rsca.api.Member
+	2,  // 26: rsca.api.Admin.RemoveHost:output_type -> rsca.api.RemoveHostResponse
+	22, // 27: rsca.api.Admin.TriggerAll:output_type -> rsca.api.TriggerAllResponse
+	23, // 28: rsca.api.Admin.TriggerInfo:output_type -> rsca.api.TriggerInfoResponse
+	4,  // 29: rsca.api.Admin.TriggerCheck:output_type -> rsca.api.TriggerCheckResponse
+	7,  // 30: rsca.api.Admin.RunCheck:output_type -> rsca.api.RunCheckResponse
+	16, // 31: rsca.api.Admin.ListResults:output_type -> rsca.api.EventMessage
+	10, // 32: rsca.api.Admin.GetResultHistory:output_type -> rsca.api.ResultHistoryResponse
+	12, // 33: rsca.api.Admin.Watch:output_type -> rsca.api.WatchEvent
+	13, // 34: rsca.api.Admin.ListAudit:output_type -> rsca.api.AuditEntry
+	25, // [25:35] is the sub-list for method output_type
+	15, // [15:25] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_github_com_na4ma4_rsca_api_admin_proto_init() }
@@ -189,13 +1859,14 @@ func file_github_com_na4ma4_rsca_api_admin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_github_com_na4ma4_rsca_api_admin_proto_rawDesc), len(file_github_com_na4ma4_rsca_api_admin_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_github_com_na4ma4_rsca_api_admin_proto_goTypes,
 		DependencyIndexes: file_github_com_na4ma4_rsca_api_admin_proto_depIdxs,
+		EnumInfos:         file_github_com_na4ma4_rsca_api_admin_proto_enumTypes,
 		MessageInfos:      file_github_com_na4ma4_rsca_api_admin_proto_msgTypes,
 	}.Build()
 	File_github_com_na4ma4_rsca_api_admin_proto = out.File
@@ -19,10 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Admin_ListHosts_FullMethodName   = "/rsca.api.Admin/ListHosts"
-	Admin_RemoveHost_FullMethodName  = "/rsca.api.Admin/RemoveHost"
-	Admin_TriggerAll_FullMethodName  = "/rsca.api.Admin/TriggerAll"
-	Admin_TriggerInfo_FullMethodName = "/rsca.api.Admin/TriggerInfo"
+	Admin_ListHosts_FullMethodName        = "/rsca.api.Admin/ListHosts"
+	Admin_RemoveHost_FullMethodName       = "/rsca.api.Admin/RemoveHost"
+	Admin_TriggerAll_FullMethodName       = "/rsca.api.Admin/TriggerAll"
+	Admin_TriggerInfo_FullMethodName      = "/rsca.api.Admin/TriggerInfo"
+	Admin_TriggerCheck_FullMethodName     = "/rsca.api.Admin/TriggerCheck"
+	Admin_RunCheck_FullMethodName         = "/rsca.api.Admin/RunCheck"
+	Admin_ListResults_FullMethodName      = "/rsca.api.Admin/ListResults"
+	Admin_GetResultHistory_FullMethodName = "/rsca.api.Admin/GetResultHistory"
+	Admin_Watch_FullMethodName            = "/rsca.api.Admin/Watch"
+	Admin_ListAudit_FullMethodName        = "/rsca.api.Admin/ListAudit"
 )
 
 // AdminClient is the client API for Admin service.
@@ -33,6 +39,12 @@ type AdminClient interface {
 	RemoveHost(ctx context.Context, in *RemoveHostRequest, opts ...grpc.CallOption) (*RemoveHostResponse, error)
 	TriggerAll(ctx context.Context, in *Members, opts ...grpc.CallOption) (*TriggerAllResponse, error)
 	TriggerInfo(ctx context.Context, in *Members, opts ...grpc.CallOption) (*TriggerInfoResponse, error)
+	TriggerCheck(ctx context.Context, in *TriggerCheckRequest, opts ...grpc.CallOption) (*TriggerCheckResponse, error)
+	RunCheck(ctx context.Context, in *RunCheckRequest, opts ...grpc.CallOption) (*RunCheckResponse, error)
+	ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventMessage], error)
+	GetResultHistory(ctx context.Context, in *ResultHistoryRequest, opts ...grpc.CallOption) (*ResultHistoryResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+	ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEntry], error)
 }
 
 type adminClient struct {
@@ -92,6 +104,93 @@ func (c *adminClient) TriggerInfo(ctx context.Context, in *Members, opts ...grpc
 	return out, nil
 }
 
+func (c *adminClient) TriggerCheck(ctx context.Context, in *TriggerCheckRequest, opts ...grpc.CallOption) (*TriggerCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerCheckResponse)
+	err := c.cc.Invoke(ctx, Admin_TriggerCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RunCheck(ctx context.Context, in *RunCheckRequest, opts ...grpc.CallOption) (*RunCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunCheckResponse)
+	err := c.cc.Invoke(ctx, Admin_RunCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], Admin_ListResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListResultsRequest, EventMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ListResultsClient = grpc.ServerStreamingClient[EventMessage]
+
+func (c *adminClient) GetResultHistory(ctx context.Context, in *ResultHistoryRequest, opts ...grpc.CallOption) (*ResultHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResultHistoryResponse)
+	err := c.cc.Invoke(ctx, Admin_GetResultHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[2], Admin_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
+func (c *adminClient) ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[3], Admin_ListAudit_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAuditRequest, AuditEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ListAuditClient = grpc.ServerStreamingClient[AuditEntry]
+
 // AdminServer is the server API for Admin service.
 // All implementations should embed UnimplementedAdminServer
 // for forward compatibility.
@@ -100,6 +199,12 @@ type AdminServer interface {
 	RemoveHost(context.Context, *RemoveHostRequest) (*RemoveHostResponse, error)
 	TriggerAll(context.Context, *Members) (*TriggerAllResponse, error)
 	TriggerInfo(context.Context, *Members) (*TriggerInfoResponse, error)
+	TriggerCheck(context.Context, *TriggerCheckRequest) (*TriggerCheckResponse, error)
+	RunCheck(context.Context, *RunCheckRequest) (*RunCheckResponse, error)
+	ListResults(*ListResultsRequest, grpc.ServerStreamingServer[EventMessage]) error
+	GetResultHistory(context.Context, *ResultHistoryRequest) (*ResultHistoryResponse, error)
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	ListAudit(*ListAuditRequest, grpc.ServerStreamingServer[AuditEntry]) error
 }
 
 // UnimplementedAdminServer should be embedded to have
@@ -121,6 +226,24 @@ func (UnimplementedAdminServer) TriggerAll(context.Context, *Members) (*TriggerA
 func (UnimplementedAdminServer) TriggerInfo(context.Context, *Members) (*TriggerInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TriggerInfo not implemented")
 }
+func (UnimplementedAdminServer) TriggerCheck(context.Context, *TriggerCheckRequest) (*TriggerCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerCheck not implemented")
+}
+func (UnimplementedAdminServer) RunCheck(context.Context, *RunCheckRequest) (*RunCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCheck not implemented")
+}
+func (UnimplementedAdminServer) ListResults(*ListResultsRequest, grpc.ServerStreamingServer[EventMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method ListResults not implemented")
+}
+func (UnimplementedAdminServer) GetResultHistory(context.Context, *ResultHistoryRequest) (*ResultHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultHistory not implemented")
+}
+func (UnimplementedAdminServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedAdminServer) ListAudit(*ListAuditRequest, grpc.ServerStreamingServer[AuditEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAudit not implemented")
+}
 func (UnimplementedAdminServer) testEmbeddedByValue() {}
 
 // UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
@@ -206,6 +329,93 @@ func _Admin_TriggerInfo_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_TriggerCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).TriggerCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_TriggerCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).TriggerCheck(ctx, req.(*TriggerCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RunCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RunCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RunCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RunCheck(ctx, req.(*RunCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ListResults(m, &grpc.GenericServerStream[ListResultsRequest, EventMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ListResultsServer = grpc.ServerStreamingServer[EventMessage]
+
+func _Admin_GetResultHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetResultHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetResultHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetResultHistory(ctx, req.(*ResultHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
+func _Admin_ListAudit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAuditRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ListAudit(m, &grpc.GenericServerStream[ListAuditRequest, AuditEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_ListAuditServer = grpc.ServerStreamingServer[AuditEntry]
+
 // Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -225,6 +435,18 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TriggerInfo",
 			Handler:    _Admin_TriggerInfo_Handler,
 		},
+		{
+			MethodName: "TriggerCheck",
+			Handler:    _Admin_TriggerCheck_Handler,
+		},
+		{
+			MethodName: "RunCheck",
+			Handler:    _Admin_RunCheck_Handler,
+		},
+		{
+			MethodName: "GetResultHistory",
+			Handler:    _Admin_GetResultHistory_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -232,6 +454,21 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Admin_ListHosts_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListResults",
+			Handler:       _Admin_ListResults_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Admin_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAudit",
+			Handler:       _Admin_ListAudit_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "github.com/na4ma4/rsca/api/admin.proto",
 }
@@ -1,5 +1,7 @@
 package api
 
+import "strings"
+
 // // MembersByName returns a member from a supplied name.
 // func MembersByName(name string) *Members {
 // 	return &Members{
@@ -45,3 +47,24 @@ func ExitCodeToStatus(exitCode int) Status {
 
 	return Status_UNKNOWN
 }
+
+// IsSoftState returns true when the check result is a problem that has not yet failed enough
+// consecutive times to be reported as a hard state.
+func (x *EventMessage) IsSoftState() bool {
+	return x.GetStatus() != Status_OK && x.GetRetries() < x.GetMaxRetries()
+}
+
+// PluginOutput returns the check result in the `output|perfdata\nlong output` format.
+func (x *EventMessage) PluginOutput() string {
+	out := strings.TrimSpace(x.GetOutput())
+
+	if v := strings.TrimSpace(x.GetPerfdata()); v != "" {
+		out += "|" + v
+	}
+
+	if v := strings.TrimSpace(x.GetLongOutput()); v != "" {
+		out += "\n" + v
+	}
+
+	return out
+}
@@ -475,28 +475,30 @@ func (b0 Members_builder) Build() *Members {
 }
 
 type Member struct {
-	state                   protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id           *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_InternalId   *string                `protobuf:"bytes,2,opt,name=internal_id,json=internalId"`
-	xxx_hidden_Name         *string                `protobuf:"bytes,10,opt,name=name"`
-	xxx_hidden_Capability   []string               `protobuf:"bytes,11,rep,name=capability"`
-	xxx_hidden_Tag          []string               `protobuf:"bytes,12,rep,name=tag"`
-	xxx_hidden_Service      []string               `protobuf:"bytes,13,rep,name=service"`
-	xxx_hidden_Version      *string                `protobuf:"bytes,90,opt,name=version"`
-	xxx_hidden_GitHash      *string                `protobuf:"bytes,91,opt,name=git_hash,json=gitHash"`
-	xxx_hidden_BuildDate    *string                `protobuf:"bytes,92,opt,name=build_date,json=buildDate"`
-	xxx_hidden_LastSeen     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=last_seen,json=lastSeen"`
-	xxx_hidden_PingLatency  *durationpb.Duration   `protobuf:"bytes,102,opt,name=ping_latency,json=pingLatency"`
-	xxx_hidden_InfoStat     *InfoStat              `protobuf:"bytes,200,opt,name=info_stat,json=infoStat"`
-	xxx_hidden_SystemStart  *timestamppb.Timestamp `protobuf:"bytes,201,opt,name=system_start,json=systemStart"`
-	xxx_hidden_ProcessStart *timestamppb.Timestamp `protobuf:"bytes,202,opt,name=process_start,json=processStart"`
-	xxx_hidden_Active       bool                   `protobuf:"varint,203,opt,name=active"`
-	xxx_hidden_LastSeenAgo  *string                `protobuf:"bytes,1001,opt,name=last_seen_ago,json=lastSeenAgo"`
-	xxx_hidden_Latency      *string                `protobuf:"bytes,1003,opt,name=latency"`
-	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
-	XXX_presence            [1]uint32
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	state                    protoimpl.MessageState          `protogen:"opaque.v1"`
+	xxx_hidden_Id            *string                         `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_InternalId    *string                         `protobuf:"bytes,2,opt,name=internal_id,json=internalId"`
+	xxx_hidden_Name          *string                         `protobuf:"bytes,10,opt,name=name"`
+	xxx_hidden_Capability    []string                        `protobuf:"bytes,11,rep,name=capability"`
+	xxx_hidden_Tag           []string                        `protobuf:"bytes,12,rep,name=tag"`
+	xxx_hidden_Service       []string                        `protobuf:"bytes,13,rep,name=service"`
+	xxx_hidden_ServicePeriod map[string]*durationpb.Duration `protobuf:"bytes,14,rep,name=service_period,json=servicePeriod" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Version       *string                         `protobuf:"bytes,90,opt,name=version"`
+	xxx_hidden_GitHash       *string                         `protobuf:"bytes,91,opt,name=git_hash,json=gitHash"`
+	xxx_hidden_BuildDate     *string                         `protobuf:"bytes,92,opt,name=build_date,json=buildDate"`
+	xxx_hidden_LastSeen      *timestamppb.Timestamp          `protobuf:"bytes,100,opt,name=last_seen,json=lastSeen"`
+	xxx_hidden_PingLatency   *durationpb.Duration            `protobuf:"bytes,102,opt,name=ping_latency,json=pingLatency"`
+	xxx_hidden_InfoStat      *InfoStat                       `protobuf:"bytes,200,opt,name=info_stat,json=infoStat"`
+	xxx_hidden_SystemStart   *timestamppb.Timestamp          `protobuf:"bytes,201,opt,name=system_start,json=systemStart"`
+	xxx_hidden_ProcessStart  *timestamppb.Timestamp          `protobuf:"bytes,202,opt,name=process_start,json=processStart"`
+	xxx_hidden_Active        bool                            `protobuf:"varint,203,opt,name=active"`
+	xxx_hidden_Server        *string                         `protobuf:"bytes,204,opt,name=server"`
+	xxx_hidden_LastSeenAgo   *string                         `protobuf:"bytes,1001,opt,name=last_seen_ago,json=lastSeenAgo"`
+	xxx_hidden_Latency       *string                         `protobuf:"bytes,1003,opt,name=latency"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
 func (x *Member) Reset() {
@@ -575,6 +577,13 @@ func (x *Member) GetService() []string {
 	return nil
 }
 
+func (x *Member) GetServicePeriod() map[string]*durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_ServicePeriod
+	}
+	return nil
+}
+
 func (x *Member) GetVersion() string {
 	if x != nil {
 		if x.xxx_hidden_Version != nil {
@@ -647,6 +656,16 @@ func (x *Member) GetActive() bool {
 	return false
 }
 
+func (x *Member) GetServer() string {
+	if x != nil {
+		if x.xxx_hidden_Server != nil {
+			return *x.xxx_hidden_Server
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *Member) GetLastSeenAgo() string {
 	if x != nil {
 		if x.xxx_hidden_LastSeenAgo != nil {
@@ -669,17 +688,17 @@ func (x *Member) GetLatency() string {
 
 func (x *Member) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 19)
 }
 
 func (x *Member) SetInternalId(v string) {
 	x.xxx_hidden_InternalId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 19)
 }
 
 func (x *Member) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 19)
 }
 
 func (x *Member) SetCapability(v []string) {
@@ -694,19 +713,23 @@ func (x *Member) SetService(v []string) {
 	x.xxx_hidden_Service = v
 }
 
+func (x *Member) SetServicePeriod(v map[string]*durationpb.Duration) {
+	x.xxx_hidden_ServicePeriod = v
+}
+
 func (x *Member) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 19)
 }
 
 func (x *Member) SetGitHash(v string) {
 	x.xxx_hidden_GitHash = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 19)
 }
 
 func (x *Member) SetBuildDate(v string) {
 	x.xxx_hidden_BuildDate = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 19)
 }
 
 func (x *Member) SetLastSeen(v *timestamppb.Timestamp) {
@@ -731,17 +754,22 @@ func (x *Member) SetProcessStart(v *timestamppb.Timestamp) {
 
 func (x *Member) SetActive(v bool) {
 	x.xxx_hidden_Active = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 19)
+}
+
+func (x *Member) SetServer(v string) {
+	x.xxx_hidden_Server = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 19)
 }
 
 func (x *Member) SetLastSeenAgo(v string) {
 	x.xxx_hidden_LastSeenAgo = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 19)
 }
 
 func (x *Member) SetLatency(v string) {
 	x.xxx_hidden_Latency = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 17)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 18, 19)
 }
 
 func (x *Member) HasId() bool {
@@ -769,21 +797,21 @@ func (x *Member) HasVersion() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
 }
 
 func (x *Member) HasGitHash() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
 }
 
 func (x *Member) HasBuildDate() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
 }
 
 func (x *Member) HasLastSeen() bool {
@@ -825,21 +853,28 @@ func (x *Member) HasActive() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 14)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 15)
+}
+
+func (x *Member) HasServer() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 16)
 }
 
 func (x *Member) HasLastSeenAgo() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 15)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 17)
 }
 
 func (x *Member) HasLatency() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 16)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 18)
 }
 
 func (x *Member) ClearId() {
@@ -858,17 +893,17 @@ func (x *Member) ClearName() {
 }
 
 func (x *Member) ClearVersion() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
 	x.xxx_hidden_Version = nil
 }
 
 func (x *Member) ClearGitHash() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
 	x.xxx_hidden_GitHash = nil
 }
 
 func (x *Member) ClearBuildDate() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
 	x.xxx_hidden_BuildDate = nil
 }
 
@@ -893,38 +928,47 @@ func (x *Member) ClearProcessStart() {
 }
 
 func (x *Member) ClearActive() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 14)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 15)
 	x.xxx_hidden_Active = false
 }
 
+func (x *Member) ClearServer() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 16)
+	x.xxx_hidden_Server = nil
+}
+
 func (x *Member) ClearLastSeenAgo() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 15)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 17)
 	x.xxx_hidden_LastSeenAgo = nil
 }
 
 func (x *Member) ClearLatency() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 16)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 18)
 	x.xxx_hidden_Latency = nil
 }
 
 type Member_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id           *string
-	InternalId   *string
-	Name         *string
-	Capability   []string
-	Tag          []string
-	Service      []string
-	Version      *string
-	GitHash      *string
-	BuildDate    *string
-	LastSeen     *timestamppb.Timestamp
-	PingLatency  *durationpb.Duration
-	InfoStat     *InfoStat
-	SystemStart  *timestamppb.Timestamp
-	ProcessStart *timestamppb.Timestamp
-	Active       *bool
+	Id         *string
+	InternalId *string
+	Name       *string
+	Capability []string
+	Tag        []string
+	Service    []string
+	// Check period of each service, used by the server to detect services that have stopped reporting.
+	ServicePeriod map[string]*durationpb.Duration
+	Version       *string
+	GitHash       *string
+	BuildDate     *string
+	LastSeen      *timestamppb.Timestamp
+	PingLatency   *durationpb.Duration
+	InfoStat      *InfoStat
+	SystemStart   *timestamppb.Timestamp
+	ProcessStart  *timestamppb.Timestamp
+	Active        *bool
+	// Address of the server the client is currently connected to.
+	Server *string
 	// Only used in rendering host lists, not transferred over the wire.
 	LastSeenAgo *string
 	Latency     *string
@@ -935,30 +979,31 @@ func (b0 Member_builder) Build() *Member {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 19)
 		x.xxx_hidden_Id = b.Id
 	}
 	if b.InternalId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 19)
 		x.xxx_hidden_InternalId = b.InternalId
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 19)
 		x.xxx_hidden_Name = b.Name
 	}
 	x.xxx_hidden_Capability = b.Capability
 	x.xxx_hidden_Tag = b.Tag
 	x.xxx_hidden_Service = b.Service
+	x.xxx_hidden_ServicePeriod = b.ServicePeriod
 	if b.Version != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 19)
 		x.xxx_hidden_Version = b.Version
 	}
 	if b.GitHash != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 19)
 		x.xxx_hidden_GitHash = b.GitHash
 	}
 	if b.BuildDate != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 19)
 		x.xxx_hidden_BuildDate = b.BuildDate
 	}
 	x.xxx_hidden_LastSeen = b.LastSeen
@@ -967,15 +1012,19 @@ func (b0 Member_builder) Build() *Member {
 	x.xxx_hidden_SystemStart = b.SystemStart
 	x.xxx_hidden_ProcessStart = b.ProcessStart
 	if b.Active != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 19)
 		x.xxx_hidden_Active = *b.Active
 	}
+	if b.Server != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 19)
+		x.xxx_hidden_Server = b.Server
+	}
 	if b.LastSeenAgo != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 19)
 		x.xxx_hidden_LastSeenAgo = b.LastSeenAgo
 	}
 	if b.Latency != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 17)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 18, 19)
 		x.xxx_hidden_Latency = b.Latency
 	}
 	return m0
@@ -1583,6 +1632,42 @@ func (x *Message) GetMemberUpdateMessage() *MemberUpdateMessage {
 	return nil
 }
 
+func (x *Message) GetEventAckMessage() *EventAckMessage {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Message.(*message_EventAckMessage); ok {
+			return x.EventAckMessage
+		}
+	}
+	return nil
+}
+
+func (x *Message) GetTriggerCheckMessage() *TriggerCheckMessage {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Message.(*message_TriggerCheckMessage); ok {
+			return x.TriggerCheckMessage
+		}
+	}
+	return nil
+}
+
+func (x *Message) GetRunCheckMessage() *RunCheckMessage {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Message.(*message_RunCheckMessage); ok {
+			return x.RunCheckMessage
+		}
+	}
+	return nil
+}
+
+func (x *Message) GetRunCheckResultMessage() *RunCheckResultMessage {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Message.(*message_RunCheckResultMessage); ok {
+			return x.RunCheckResultMessage
+		}
+	}
+	return nil
+}
+
 func (x *Message) SetEnvelope(v *Envelope) {
 	x.xxx_hidden_Envelope = v
 }
@@ -1643,6 +1728,38 @@ func (x *Message) SetMemberUpdateMessage(v *MemberUpdateMessage) {
 	x.xxx_hidden_Message = &message_MemberUpdateMessage{v}
 }
 
+func (x *Message) SetEventAckMessage(v *EventAckMessage) {
+	if v == nil {
+		x.xxx_hidden_Message = nil
+		return
+	}
+	x.xxx_hidden_Message = &message_EventAckMessage{v}
+}
+
+func (x *Message) SetTriggerCheckMessage(v *TriggerCheckMessage) {
+	if v == nil {
+		x.xxx_hidden_Message = nil
+		return
+	}
+	x.xxx_hidden_Message = &message_TriggerCheckMessage{v}
+}
+
+func (x *Message) SetRunCheckMessage(v *RunCheckMessage) {
+	if v == nil {
+		x.xxx_hidden_Message = nil
+		return
+	}
+	x.xxx_hidden_Message = &message_RunCheckMessage{v}
+}
+
+func (x *Message) SetRunCheckResultMessage(v *RunCheckResultMessage) {
+	if v == nil {
+		x.xxx_hidden_Message = nil
+		return
+	}
+	x.xxx_hidden_Message = &message_RunCheckResultMessage{v}
+}
+
 func (x *Message) HasEnvelope() bool {
 	if x == nil {
 		return false
@@ -1713,6 +1830,38 @@ func (x *Message) HasMemberUpdateMessage() bool {
 	return ok
 }
 
+func (x *Message) HasEventAckMessage() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Message.(*message_EventAckMessage)
+	return ok
+}
+
+func (x *Message) HasTriggerCheckMessage() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Message.(*message_TriggerCheckMessage)
+	return ok
+}
+
+func (x *Message) HasRunCheckMessage() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Message.(*message_RunCheckMessage)
+	return ok
+}
+
+func (x *Message) HasRunCheckResultMessage() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Message.(*message_RunCheckResultMessage)
+	return ok
+}
+
 func (x *Message) ClearEnvelope() {
 	x.xxx_hidden_Envelope = nil
 }
@@ -1763,6 +1912,30 @@ func (x *Message) ClearMemberUpdateMessage() {
 	}
 }
 
+func (x *Message) ClearEventAckMessage() {
+	if _, ok := x.xxx_hidden_Message.(*message_EventAckMessage); ok {
+		x.xxx_hidden_Message = nil
+	}
+}
+
+func (x *Message) ClearTriggerCheckMessage() {
+	if _, ok := x.xxx_hidden_Message.(*message_TriggerCheckMessage); ok {
+		x.xxx_hidden_Message = nil
+	}
+}
+
+func (x *Message) ClearRunCheckMessage() {
+	if _, ok := x.xxx_hidden_Message.(*message_RunCheckMessage); ok {
+		x.xxx_hidden_Message = nil
+	}
+}
+
+func (x *Message) ClearRunCheckResultMessage() {
+	if _, ok := x.xxx_hidden_Message.(*message_RunCheckResultMessage); ok {
+		x.xxx_hidden_Message = nil
+	}
+}
+
 const Message_Message_not_set_case case_Message_Message = 0
 const Message_RegisterMessage_case case_Message_Message = 100
 const Message_PingMessage_case case_Message_Message = 101
@@ -1771,6 +1944,10 @@ const Message_EventMessage_case case_Message_Message = 103
 const Message_TriggerAllMessage_case case_Message_Message = 104
 const Message_RepeatRegistrationMessage_case case_Message_Message = 105
 const Message_MemberUpdateMessage_case case_Message_Message = 106
+const Message_EventAckMessage_case case_Message_Message = 107
+const Message_TriggerCheckMessage_case case_Message_Message = 108
+const Message_RunCheckMessage_case case_Message_Message = 109
+const Message_RunCheckResultMessage_case case_Message_Message = 110
 
 func (x *Message) WhichMessage() case_Message_Message {
 	if x == nil {
@@ -1791,6 +1968,14 @@ func (x *Message) WhichMessage() case_Message_Message {
 		return Message_RepeatRegistrationMessage_case
 	case *message_MemberUpdateMessage:
 		return Message_MemberUpdateMessage_case
+	case *message_EventAckMessage:
+		return Message_EventAckMessage_case
+	case *message_TriggerCheckMessage:
+		return Message_TriggerCheckMessage_case
+	case *message_RunCheckMessage:
+		return Message_RunCheckMessage_case
+	case *message_RunCheckResultMessage:
+		return Message_RunCheckResultMessage_case
 	default:
 		return Message_Message_not_set_case
 	}
@@ -1808,6 +1993,10 @@ type Message_builder struct {
 	TriggerAllMessage         *TriggerAllMessage
 	RepeatRegistrationMessage *RepeatRegistrationMessage
 	MemberUpdateMessage       *MemberUpdateMessage
+	EventAckMessage           *EventAckMessage
+	TriggerCheckMessage       *TriggerCheckMessage
+	RunCheckMessage           *RunCheckMessage
+	RunCheckResultMessage     *RunCheckResultMessage
 	// -- end of xxx_hidden_Message
 }
 
@@ -1837,6 +2026,18 @@ func (b0 Message_builder) Build() *Message {
 	if b.MemberUpdateMessage != nil {
 		x.xxx_hidden_Message = &message_MemberUpdateMessage{b.MemberUpdateMessage}
 	}
+	if b.EventAckMessage != nil {
+		x.xxx_hidden_Message = &message_EventAckMessage{b.EventAckMessage}
+	}
+	if b.TriggerCheckMessage != nil {
+		x.xxx_hidden_Message = &message_TriggerCheckMessage{b.TriggerCheckMessage}
+	}
+	if b.RunCheckMessage != nil {
+		x.xxx_hidden_Message = &message_RunCheckMessage{b.RunCheckMessage}
+	}
+	if b.RunCheckResultMessage != nil {
+		x.xxx_hidden_Message = &message_RunCheckResultMessage{b.RunCheckResultMessage}
+	}
 	return m0
 }
 
@@ -1882,6 +2083,22 @@ type message_MemberUpdateMessage struct {
 	MemberUpdateMessage *MemberUpdateMessage `protobuf:"bytes,106,opt,name=member_update_message,json=memberUpdateMessage,oneof"`
 }
 
+type message_EventAckMessage struct {
+	EventAckMessage *EventAckMessage `protobuf:"bytes,107,opt,name=event_ack_message,json=eventAckMessage,oneof"`
+}
+
+type message_TriggerCheckMessage struct {
+	TriggerCheckMessage *TriggerCheckMessage `protobuf:"bytes,108,opt,name=trigger_check_message,json=triggerCheckMessage,oneof"`
+}
+
+type message_RunCheckMessage struct {
+	RunCheckMessage *RunCheckMessage `protobuf:"bytes,109,opt,name=run_check_message,json=runCheckMessage,oneof"`
+}
+
+type message_RunCheckResultMessage struct {
+	RunCheckResultMessage *RunCheckResultMessage `protobuf:"bytes,110,opt,name=run_check_result_message,json=runCheckResultMessage,oneof"`
+}
+
 func (*message_RegisterMessage) isMessage_Message() {}
 
 func (*message_PingMessage) isMessage_Message() {}
@@ -1896,6 +2113,14 @@ func (*message_RepeatRegistrationMessage) isMessage_Message() {}
 
 func (*message_MemberUpdateMessage) isMessage_Message() {}
 
+func (*message_EventAckMessage) isMessage_Message() {}
+
+func (*message_TriggerCheckMessage) isMessage_Message() {}
+
+func (*message_RunCheckMessage) isMessage_Message() {}
+
+func (*message_RunCheckResultMessage) isMessage_Message() {}
+
 type RegisterMessage struct {
 	state             protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Member *Member                `protobuf:"bytes,1,opt,name=member"`
@@ -2392,27 +2617,32 @@ func (b0 RepeatRegistrationMessage_builder) Build() *RepeatRegistrationMessage {
 	return m0
 }
 
-type MemberUpdateMessage struct {
-	state             protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Member *Member                `protobuf:"bytes,1,opt,name=member"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// RunCheckMessage requests that the client runs a check immediately and replies with a
+// RunCheckResultMessage with the same id.
+type RunCheckMessage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Check       *string                `protobuf:"bytes,2,opt,name=check"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *MemberUpdateMessage) Reset() {
-	*x = MemberUpdateMessage{}
+func (x *RunCheckMessage) Reset() {
+	*x = RunCheckMessage{}
 	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MemberUpdateMessage) String() string {
+func (x *RunCheckMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemberUpdateMessage) ProtoMessage() {}
+func (*RunCheckMessage) ProtoMessage() {}
 
-func (x *MemberUpdateMessage) ProtoReflect() protoreflect.Message {
+func (x *RunCheckMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2424,74 +2654,108 @@ func (x *MemberUpdateMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *MemberUpdateMessage) GetMember() *Member {
+func (x *RunCheckMessage) GetId() string {
 	if x != nil {
-		return x.xxx_hidden_Member
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *MemberUpdateMessage) SetMember(v *Member) {
-	x.xxx_hidden_Member = v
+func (x *RunCheckMessage) GetCheck() string {
+	if x != nil {
+		if x.xxx_hidden_Check != nil {
+			return *x.xxx_hidden_Check
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *MemberUpdateMessage) HasMember() bool {
+func (x *RunCheckMessage) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RunCheckMessage) SetCheck(v string) {
+	x.xxx_hidden_Check = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RunCheckMessage) HasId() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Member != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *MemberUpdateMessage) ClearMember() {
-	x.xxx_hidden_Member = nil
+func (x *RunCheckMessage) HasCheck() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-type MemberUpdateMessage_builder struct {
+func (x *RunCheckMessage) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *RunCheckMessage) ClearCheck() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Check = nil
+}
+
+type RunCheckMessage_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Member *Member
+	Id    *string
+	Check *string
 }
 
-func (b0 MemberUpdateMessage_builder) Build() *MemberUpdateMessage {
-	m0 := &MemberUpdateMessage{}
+func (b0 RunCheckMessage_builder) Build() *RunCheckMessage {
+	m0 := &RunCheckMessage{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Member = b.Member
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Check != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Check = b.Check
+	}
 	return m0
 }
 
-type EventMessage struct {
-	state                       protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Hostname         *string                `protobuf:"bytes,1,opt,name=hostname"`
-	xxx_hidden_Type             CheckType              `protobuf:"varint,2,opt,name=type,enum=rsca.api.CheckType"`
-	xxx_hidden_Check            *string                `protobuf:"bytes,3,opt,name=check"`
-	xxx_hidden_Status           Status                 `protobuf:"varint,4,opt,name=status,enum=rsca.api.Status"`
-	xxx_hidden_Output           *string                `protobuf:"bytes,5,opt,name=output"`
-	xxx_hidden_OutputError      *string                `protobuf:"bytes,10,opt,name=output_error,json=outputError"`
-	xxx_hidden_Perfdata         *string                `protobuf:"bytes,6,opt,name=perfdata"`
-	xxx_hidden_RequestTimestamp *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=request_timestamp,json=requestTimestamp"`
-	xxx_hidden_Retries          int32                  `protobuf:"varint,8,opt,name=retries"`
-	xxx_hidden_Id               *string                `protobuf:"bytes,9,opt,name=id"`
-	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
-	XXX_presence                [1]uint32
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+// RunCheckResultMessage is the reply to a RunCheckMessage.
+type RunCheckResultMessage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Event       *EventMessage          `protobuf:"bytes,2,opt,name=event"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *EventMessage) Reset() {
-	*x = EventMessage{}
+func (x *RunCheckResultMessage) Reset() {
+	*x = RunCheckResultMessage{}
 	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EventMessage) String() string {
+func (x *RunCheckResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventMessage) ProtoMessage() {}
+func (*RunCheckResultMessage) ProtoMessage() {}
 
-func (x *EventMessage) ProtoReflect() protoreflect.Message {
+func (x *RunCheckResultMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2503,23 +2767,410 @@ func (x *EventMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *EventMessage) GetHostname() string {
+func (x *RunCheckResultMessage) GetId() string {
 	if x != nil {
-		if x.xxx_hidden_Hostname != nil {
-			return *x.xxx_hidden_Hostname
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *EventMessage) GetType() CheckType {
+func (x *RunCheckResultMessage) GetEvent() *EventMessage {
 	if x != nil {
-		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
-			return x.xxx_hidden_Type
-		}
+		return x.xxx_hidden_Event
 	}
-	return CheckType_HOST
+	return nil
+}
+
+func (x *RunCheckResultMessage) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RunCheckResultMessage) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *RunCheckResultMessage) SetEvent(v *EventMessage) {
+	x.xxx_hidden_Event = v
+}
+
+func (x *RunCheckResultMessage) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *RunCheckResultMessage) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RunCheckResultMessage) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *RunCheckResultMessage) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RunCheckResultMessage) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *RunCheckResultMessage) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+func (x *RunCheckResultMessage) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+type RunCheckResultMessage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id    *string
+	Event *EventMessage
+	Error *string
+}
+
+func (b0 RunCheckResultMessage_builder) Build() *RunCheckResultMessage {
+	m0 := &RunCheckResultMessage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_Event = b.Event
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// TriggerCheckMessage requests that the client re-runs the named checks.
+type TriggerCheckMessage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Checks      []string               `protobuf:"bytes,2,rep,name=checks"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TriggerCheckMessage) Reset() {
+	*x = TriggerCheckMessage{}
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerCheckMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerCheckMessage) ProtoMessage() {}
+
+func (x *TriggerCheckMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TriggerCheckMessage) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TriggerCheckMessage) GetChecks() []string {
+	if x != nil {
+		return x.xxx_hidden_Checks
+	}
+	return nil
+}
+
+func (x *TriggerCheckMessage) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *TriggerCheckMessage) SetChecks(v []string) {
+	x.xxx_hidden_Checks = v
+}
+
+func (x *TriggerCheckMessage) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TriggerCheckMessage) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type TriggerCheckMessage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id     *string
+	Checks []string
+}
+
+func (b0 TriggerCheckMessage_builder) Build() *TriggerCheckMessage {
+	m0 := &TriggerCheckMessage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_Checks = b.Checks
+	return m0
+}
+
+type MemberUpdateMessage struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Member *Member                `protobuf:"bytes,1,opt,name=member"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MemberUpdateMessage) Reset() {
+	*x = MemberUpdateMessage{}
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberUpdateMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberUpdateMessage) ProtoMessage() {}
+
+func (x *MemberUpdateMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MemberUpdateMessage) GetMember() *Member {
+	if x != nil {
+		return x.xxx_hidden_Member
+	}
+	return nil
+}
+
+func (x *MemberUpdateMessage) SetMember(v *Member) {
+	x.xxx_hidden_Member = v
+}
+
+func (x *MemberUpdateMessage) HasMember() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Member != nil
+}
+
+func (x *MemberUpdateMessage) ClearMember() {
+	x.xxx_hidden_Member = nil
+}
+
+type MemberUpdateMessage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Member *Member
+}
+
+func (b0 MemberUpdateMessage_builder) Build() *MemberUpdateMessage {
+	m0 := &MemberUpdateMessage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Member = b.Member
+	return m0
+}
+
+// EventAckMessage is sent by the server once an EventMessage has been written out.
+type EventAckMessage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EventAckMessage) Reset() {
+	*x = EventAckMessage{}
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventAckMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAckMessage) ProtoMessage() {}
+
+func (x *EventAckMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EventAckMessage) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EventAckMessage) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *EventAckMessage) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *EventAckMessage) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type EventAckMessage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 EventAckMessage_builder) Build() *EventAckMessage {
+	m0 := &EventAckMessage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type EventMessage struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Hostname         *string                `protobuf:"bytes,1,opt,name=hostname"`
+	xxx_hidden_Type             CheckType              `protobuf:"varint,2,opt,name=type,enum=rsca.api.CheckType"`
+	xxx_hidden_Check            *string                `protobuf:"bytes,3,opt,name=check"`
+	xxx_hidden_Status           Status                 `protobuf:"varint,4,opt,name=status,enum=rsca.api.Status"`
+	xxx_hidden_Output           *string                `protobuf:"bytes,5,opt,name=output"`
+	xxx_hidden_OutputError      *string                `protobuf:"bytes,10,opt,name=output_error,json=outputError"`
+	xxx_hidden_LongOutput       *string                `protobuf:"bytes,11,opt,name=long_output,json=longOutput"`
+	xxx_hidden_Perfdata         *string                `protobuf:"bytes,6,opt,name=perfdata"`
+	xxx_hidden_RequestTimestamp *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=request_timestamp,json=requestTimestamp"`
+	xxx_hidden_Retries          int32                  `protobuf:"varint,8,opt,name=retries"`
+	xxx_hidden_Id               *string                `protobuf:"bytes,9,opt,name=id"`
+	xxx_hidden_MaxRetries       int32                  `protobuf:"varint,12,opt,name=max_retries,json=maxRetries"`
+	xxx_hidden_Resends          int32                  `protobuf:"varint,13,opt,name=resends"`
+	xxx_hidden_Duration         *durationpb.Duration   `protobuf:"bytes,14,opt,name=duration"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *EventMessage) Reset() {
+	*x = EventMessage{}
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventMessage) ProtoMessage() {}
+
+func (x *EventMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_common_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EventMessage) GetHostname() string {
+	if x != nil {
+		if x.xxx_hidden_Hostname != nil {
+			return *x.xxx_hidden_Hostname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EventMessage) GetType() CheckType {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return CheckType_HOST
 }
 
 func (x *EventMessage) GetCheck() string {
@@ -2561,6 +3212,16 @@ func (x *EventMessage) GetOutputError() string {
 	return ""
 }
 
+func (x *EventMessage) GetLongOutput() string {
+	if x != nil {
+		if x.xxx_hidden_LongOutput != nil {
+			return *x.xxx_hidden_LongOutput
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *EventMessage) GetPerfdata() string {
 	if x != nil {
 		if x.xxx_hidden_Perfdata != nil {
@@ -2595,39 +3256,65 @@ func (x *EventMessage) GetId() string {
 	return ""
 }
 
+func (x *EventMessage) GetMaxRetries() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxRetries
+	}
+	return 0
+}
+
+func (x *EventMessage) GetResends() int32 {
+	if x != nil {
+		return x.xxx_hidden_Resends
+	}
+	return 0
+}
+
+func (x *EventMessage) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Duration
+	}
+	return nil
+}
+
 func (x *EventMessage) SetHostname(v string) {
 	x.xxx_hidden_Hostname = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 14)
 }
 
 func (x *EventMessage) SetType(v CheckType) {
 	x.xxx_hidden_Type = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 14)
 }
 
 func (x *EventMessage) SetCheck(v string) {
 	x.xxx_hidden_Check = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 14)
 }
 
 func (x *EventMessage) SetStatus(v Status) {
 	x.xxx_hidden_Status = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 14)
 }
 
 func (x *EventMessage) SetOutput(v string) {
 	x.xxx_hidden_Output = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 14)
 }
 
 func (x *EventMessage) SetOutputError(v string) {
 	x.xxx_hidden_OutputError = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 14)
+}
+
+func (x *EventMessage) SetLongOutput(v string) {
+	x.xxx_hidden_LongOutput = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 14)
 }
 
 func (x *EventMessage) SetPerfdata(v string) {
 	x.xxx_hidden_Perfdata = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 14)
 }
 
 func (x *EventMessage) SetRequestTimestamp(v *timestamppb.Timestamp) {
@@ -2636,12 +3323,26 @@ func (x *EventMessage) SetRequestTimestamp(v *timestamppb.Timestamp) {
 
 func (x *EventMessage) SetRetries(v int32) {
 	x.xxx_hidden_Retries = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 14)
 }
 
 func (x *EventMessage) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 14)
+}
+
+func (x *EventMessage) SetMaxRetries(v int32) {
+	x.xxx_hidden_MaxRetries = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 14)
+}
+
+func (x *EventMessage) SetResends(v int32) {
+	x.xxx_hidden_Resends = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 14)
+}
+
+func (x *EventMessage) SetDuration(v *durationpb.Duration) {
+	x.xxx_hidden_Duration = v
 }
 
 func (x *EventMessage) HasHostname() bool {
@@ -2686,13 +3387,20 @@ func (x *EventMessage) HasOutputError() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *EventMessage) HasPerfdata() bool {
+func (x *EventMessage) HasLongOutput() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
+func (x *EventMessage) HasPerfdata() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
 func (x *EventMessage) HasRequestTimestamp() bool {
 	if x == nil {
 		return false
@@ -2704,14 +3412,35 @@ func (x *EventMessage) HasRetries() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
 }
 
 func (x *EventMessage) HasId() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *EventMessage) HasMaxRetries() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *EventMessage) HasResends() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *EventMessage) HasDuration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Duration != nil
 }
 
 func (x *EventMessage) ClearHostname() {
@@ -2744,8 +3473,13 @@ func (x *EventMessage) ClearOutputError() {
 	x.xxx_hidden_OutputError = nil
 }
 
-func (x *EventMessage) ClearPerfdata() {
+func (x *EventMessage) ClearLongOutput() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_LongOutput = nil
+}
+
+func (x *EventMessage) ClearPerfdata() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
 	x.xxx_hidden_Perfdata = nil
 }
 
@@ -2754,15 +3488,29 @@ func (x *EventMessage) ClearRequestTimestamp() {
 }
 
 func (x *EventMessage) ClearRetries() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
 	x.xxx_hidden_Retries = 0
 }
 
 func (x *EventMessage) ClearId() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
 	x.xxx_hidden_Id = nil
 }
 
+func (x *EventMessage) ClearMaxRetries() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_MaxRetries = 0
+}
+
+func (x *EventMessage) ClearResends() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_Resends = 0
+}
+
+func (x *EventMessage) ClearDuration() {
+	x.xxx_hidden_Duration = nil
+}
+
 type EventMessage_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -2772,10 +3520,14 @@ type EventMessage_builder struct {
 	Status           *Status
 	Output           *string
 	OutputError      *string
+	LongOutput       *string
 	Perfdata         *string
 	RequestTimestamp *timestamppb.Timestamp
 	Retries          *int32
 	Id               *string
+	MaxRetries       *int32
+	Resends          *int32
+	Duration         *durationpb.Duration
 }
 
 func (b0 EventMessage_builder) Build() *EventMessage {
@@ -2783,42 +3535,55 @@ func (b0 EventMessage_builder) Build() *EventMessage {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Hostname != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 14)
 		x.xxx_hidden_Hostname = b.Hostname
 	}
 	if b.Type != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 14)
 		x.xxx_hidden_Type = *b.Type
 	}
 	if b.Check != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 14)
 		x.xxx_hidden_Check = b.Check
 	}
 	if b.Status != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 14)
 		x.xxx_hidden_Status = *b.Status
 	}
 	if b.Output != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 14)
 		x.xxx_hidden_Output = b.Output
 	}
 	if b.OutputError != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 14)
 		x.xxx_hidden_OutputError = b.OutputError
 	}
+	if b.LongOutput != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 14)
+		x.xxx_hidden_LongOutput = b.LongOutput
+	}
 	if b.Perfdata != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 14)
 		x.xxx_hidden_Perfdata = b.Perfdata
 	}
 	x.xxx_hidden_RequestTimestamp = b.RequestTimestamp
 	if b.Retries != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 14)
 		x.xxx_hidden_Retries = *b.Retries
 	}
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 14)
 		x.xxx_hidden_Id = b.Id
 	}
+	if b.MaxRetries != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 14)
+		x.xxx_hidden_MaxRetries = *b.MaxRetries
+	}
+	if b.Resends != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 14)
+		x.xxx_hidden_Resends = *b.Resends
+	}
+	x.xxx_hidden_Duration = b.Duration
 	return m0
 }
 
@@ -2844,7 +3609,7 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"capability\x18\f \x03(\tR\n" +
 	"capability\x12\x10\n" +
 	"\x03tag\x18\r \x03(\tR\x03tag\x12\x18\n" +
-	"\aservice\x18\x0e \x03(\tR\aservice\"\xf1\x04\n" +
+	"\aservice\x18\x0e \x03(\tR\aservice\"\xb3\x06\n" +
 	"\x06Member\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vinternal_id\x18\x02 \x01(\tR\n" +
@@ -2855,7 +3620,8 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"capability\x18\v \x03(\tR\n" +
 	"capability\x12\x10\n" +
 	"\x03tag\x18\f \x03(\tR\x03tag\x12\x18\n" +
-	"\aservice\x18\r \x03(\tR\aservice\x12\x18\n" +
+	"\aservice\x18\r \x03(\tR\aservice\x12J\n" +
+	"\x0eservice_period\x18\x0e \x03(\v2#.rsca.api.Member.ServicePeriodEntryR\rservicePeriod\x12\x18\n" +
 	"\aversion\x18Z \x01(\tR\aversion\x12\x19\n" +
 	"\bgit_hash\x18[ \x01(\tR\agitHash\x12\x1d\n" +
 	"\n" +
@@ -2865,9 +3631,13 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\tinfo_stat\x18\xc8\x01 \x01(\v2\x12.rsca.api.InfoStatR\binfoStat\x12>\n" +
 	"\fsystem_start\x18\xc9\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vsystemStart\x12@\n" +
 	"\rprocess_start\x18\xca\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fprocessStart\x12\x17\n" +
-	"\x06active\x18\xcb\x01 \x01(\bR\x06active\x12#\n" +
+	"\x06active\x18\xcb\x01 \x01(\bR\x06active\x12\x17\n" +
+	"\x06server\x18\xcc\x01 \x01(\tR\x06server\x12#\n" +
 	"\rlast_seen_ago\x18\xe9\a \x01(\tR\vlastSeenAgo\x12\x19\n" +
-	"\alatency\x18\xeb\a \x01(\tR\alatency\"\xca\x03\n" +
+	"\alatency\x18\xeb\a \x01(\tR\alatency\x1a[\n" +
+	"\x12ServicePeriodEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x05value:\x028\x01\"\xca\x03\n" +
 	"\bInfoStat\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1a\n" +
 	"\bhostname\x18\x15 \x01(\tR\bhostname\x12\x16\n" +
@@ -2884,7 +3654,7 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\vvirt_system\x18\x1f \x01(\tR\n" +
 	"virtSystem\x12\x1b\n" +
 	"\tvirt_role\x18  \x01(\tR\bvirtRole\x12\x17\n" +
-	"\ahost_id\x18! \x01(\tR\x06hostId\"\xce\x04\n" +
+	"\ahost_id\x18! \x01(\tR\x06hostId\"\x91\a\n" +
 	"\aMessage\x12.\n" +
 	"\benvelope\x18\x01 \x01(\v2\x12.rsca.api.EnvelopeR\benvelope\x12F\n" +
 	"\x10register_message\x18d \x01(\v2\x19.rsca.api.RegisterMessageH\x00R\x0fregisterMessage\x12:\n" +
@@ -2893,7 +3663,11 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\revent_message\x18g \x01(\v2\x16.rsca.api.EventMessageH\x00R\feventMessage\x12M\n" +
 	"\x13trigger_all_message\x18h \x01(\v2\x1b.rsca.api.TriggerAllMessageH\x00R\x11triggerAllMessage\x12e\n" +
 	"\x1brepeat_registration_message\x18i \x01(\v2#.rsca.api.RepeatRegistrationMessageH\x00R\x19repeatRegistrationMessage\x12S\n" +
-	"\x15member_update_message\x18j \x01(\v2\x1d.rsca.api.MemberUpdateMessageH\x00R\x13memberUpdateMessageB\t\n" +
+	"\x15member_update_message\x18j \x01(\v2\x1d.rsca.api.MemberUpdateMessageH\x00R\x13memberUpdateMessage\x12G\n" +
+	"\x11event_ack_message\x18k \x01(\v2\x19.rsca.api.EventAckMessageH\x00R\x0feventAckMessage\x12S\n" +
+	"\x15trigger_check_message\x18l \x01(\v2\x1d.rsca.api.TriggerCheckMessageH\x00R\x13triggerCheckMessage\x12G\n" +
+	"\x11run_check_message\x18m \x01(\v2\x19.rsca.api.RunCheckMessageH\x00R\x0frunCheckMessage\x12Z\n" +
+	"\x18run_check_result_message\x18n \x01(\v2\x1f.rsca.api.RunCheckResultMessageH\x00R\x15runCheckResultMessageB\t\n" +
 	"\amessage\";\n" +
 	"\x0fRegisterMessage\x12(\n" +
 	"\x06member\x18\x01 \x01(\v2\x10.rsca.api.MemberR\x06member\"f\n" +
@@ -2908,9 +3682,21 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\x11TriggerAllMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
 	"\x19RepeatRegistrationMessage\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"7\n" +
+	"\x0fRunCheckMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\"k\n" +
+	"\x15RunCheckResultMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x05event\x18\x02 \x01(\v2\x16.rsca.api.EventMessageR\x05event\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"=\n" +
+	"\x13TriggerCheckMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06checks\x18\x02 \x03(\tR\x06checks\"?\n" +
 	"\x13MemberUpdateMessage\x12(\n" +
-	"\x06member\x18\x01 \x01(\v2\x10.rsca.api.MemberR\x06member\"\xdd\x02\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.rsca.api.MemberR\x06member\"!\n" +
+	"\x0fEventAckMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xf0\x03\n" +
 	"\fEventMessage\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12'\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x13.rsca.api.CheckTypeR\x04type\x12\x14\n" +
@@ -2918,11 +3704,17 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2\x10.rsca.api.StatusR\x06status\x12\x16\n" +
 	"\x06output\x18\x05 \x01(\tR\x06output\x12!\n" +
 	"\foutput_error\x18\n" +
-	" \x01(\tR\voutputError\x12\x1a\n" +
+	" \x01(\tR\voutputError\x12\x1f\n" +
+	"\vlong_output\x18\v \x01(\tR\n" +
+	"longOutput\x12\x1a\n" +
 	"\bperfdata\x18\x06 \x01(\tR\bperfdata\x12G\n" +
 	"\x11request_timestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10requestTimestamp\x12\x18\n" +
 	"\aretries\x18\b \x01(\x05R\aretries\x12\x0e\n" +
-	"\x02id\x18\t \x01(\tR\x02id*8\n" +
+	"\x02id\x18\t \x01(\tR\x02id\x12\x1f\n" +
+	"\vmax_retries\x18\f \x01(\x05R\n" +
+	"maxRetries\x12\x18\n" +
+	"\aresends\x18\r \x01(\x05R\aresends\x125\n" +
+	"\bduration\x18\x0e \x01(\v2\x19.google.protobuf.DurationR\bduration*8\n" +
 	"\x06Status\x12\x06\n" +
 	"\x02OK\x10\x00\x12\v\n" +
 	"\aWARNING\x10\x01\x12\f\n" +
@@ -2933,7 +3725,7 @@ const file_github_com_na4ma4_rsca_api_common_proto_rawDesc = "" +
 	"\aSERVICE\x10\x01B$Z\x1agithub.com/na4ma4/rsca/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
 var file_github_com_na4ma4_rsca_api_common_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_github_com_na4ma4_rsca_api_common_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_github_com_na4ma4_rsca_api_common_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_github_com_na4ma4_rsca_api_common_proto_goTypes = []any{
 	(Status)(0),                       // 0: rsca.api.Status
 	(CheckType)(0),                    // 1: rsca.api.CheckType
@@ -2950,40 +3742,53 @@ var file_github_com_na4ma4_rsca_api_common_proto_goTypes = []any{
 	(*PongMessage)(nil),               // 12: rsca.api.PongMessage
 	(*TriggerAllMessage)(nil),         // 13: rsca.api.TriggerAllMessage
 	(*RepeatRegistrationMessage)(nil), // 14: rsca.api.RepeatRegistrationMessage
-	(*MemberUpdateMessage)(nil),       // 15: rsca.api.MemberUpdateMessage
-	(*EventMessage)(nil),              // 16: rsca.api.EventMessage
-	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),       // 18: google.protobuf.Duration
+	(*RunCheckMessage)(nil),           // 15: rsca.api.RunCheckMessage
+	(*RunCheckResultMessage)(nil),     // 16: rsca.api.RunCheckResultMessage
+	(*TriggerCheckMessage)(nil),       // 17: rsca.api.TriggerCheckMessage
+	(*MemberUpdateMessage)(nil),       // 18: rsca.api.MemberUpdateMessage
+	(*EventAckMessage)(nil),           // 19: rsca.api.EventAckMessage
+	(*EventMessage)(nil),              // 20: rsca.api.EventMessage
+	nil,                               // 21: rsca.api.Member.ServicePeriodEntry
+	(*timestamppb.Timestamp)(nil),     // 22: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),       // 23: google.protobuf.Duration
 }
 var file_github_com_na4ma4_rsca_api_common_proto_depIdxs = []int32{
 	7,  // 0: rsca.api.Envelope.sender:type_name -> rsca.api.Member
 	6,  // 1: rsca.api.Envelope.recipient:type_name -> rsca.api.Members
-	17, // 2: rsca.api.Member.last_seen:type_name -> google.protobuf.Timestamp
-	18, // 3: rsca.api.Member.ping_latency:type_name -> google.protobuf.Duration
-	8,  // 4: rsca.api.Member.info_stat:type_name -> rsca.api.InfoStat
-	17, // 5: rsca.api.Member.system_start:type_name -> google.protobuf.Timestamp
-	17, // 6: rsca.api.Member.process_start:type_name -> google.protobuf.Timestamp
-	17, // 7: rsca.api.InfoStat.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 8: rsca.api.Message.envelope:type_name -> rsca.api.Envelope
-	10, // 9: rsca.api.Message.register_message:type_name -> rsca.api.RegisterMessage
-	11, // 10: rsca.api.Message.ping_message:type_name -> rsca.api.PingMessage
-	12, // 11: rsca.api.Message.pong_message:type_name -> rsca.api.PongMessage
-	16, // 12: rsca.api.Message.event_message:type_name -> rsca.api.EventMessage
-	13, // 13: rsca.api.Message.trigger_all_message:type_name -> rsca.api.TriggerAllMessage
-	14, // 14: rsca.api.Message.repeat_registration_message:type_name -> rsca.api.RepeatRegistrationMessage
-	15, // 15: rsca.api.Message.member_update_message:type_name -> rsca.api.MemberUpdateMessage
-	7,  // 16: rsca.api.RegisterMessage.member:type_name -> rsca.api.Member
-	17, // 17: rsca.api.PingMessage.ts:type_name -> google.protobuf.Timestamp
-	17, // 18: rsca.api.PongMessage.ts:type_name -> google.protobuf.Timestamp
-	7,  // 19: rsca.api.MemberUpdateMessage.member:type_name -> rsca.api.Member
-	1,  // 20: rsca.api.EventMessage.type:type_name -> rsca.api.CheckType
-	0,  // 21: rsca.api.EventMessage.status:type_name -> rsca.api.Status
-	17, // 22: rsca.api.EventMessage.request_timestamp:type_name -> google.protobuf.Timestamp
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	21, // 2: rsca.api.Member.service_period:type_name -> rsca.api.Member.ServicePeriodEntry
+	22, // 3: rsca.api.Member.last_seen:type_name -> google.protobuf.Timestamp
+	23, // 4: rsca.api.Member.ping_latency:type_name -> google.protobuf.Duration
+	8,  // 5: rsca.api.Member.info_stat:type_name -> rsca.api.InfoStat
+	22, // 6: rsca.api.Member.system_start:type_name -> google.protobuf.Timestamp
+	22, // 7: rsca.api.Member.process_start:type_name -> google.protobuf.Timestamp
+	22, // 8: rsca.api.InfoStat.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 9: rsca.api.Message.envelope:type_name -> rsca.api.Envelope
+	10, // 10: rsca.api.Message.register_message:type_name -> rsca.api.RegisterMessage
+	11, // 11: rsca.api.Message.ping_message:type_name -> rsca.api.PingMessage
+	12, // 12: rsca.api.Message.pong_message:type_name -> rsca.api.PongMessage
+	20, // 13: rsca.api.Message.event_message:type_name -> rsca.api.EventMessage
+	13, // 14: rsca.api.Message.trigger_all_message:type_name -> rsca.api.TriggerAllMessage
+	14, // 15: rsca.api.Message.repeat_registration_message:type_name -> rsca.api.RepeatRegistrationMessage
+	18, // 16: rsca.api.Message.member_update_message:type_name -> rsca.api.MemberUpdateMessage
+	19, // 17: rsca.api.Message.event_ack_message:type_name -> rsca.api.EventAckMessage
+	17, // 18: rsca.api.Message.trigger_check_message:type_name -> rsca.api.TriggerCheckMessage
+	15, // 19: rsca.api.Message.run_check_message:type_name -> rsca.api.RunCheckMessage
+	16, // 20: rsca.api.Message.run_check_result_message:type_name -> rsca.api.RunCheckResultMessage
+	7,  // 21: rsca.api.RegisterMessage.member:type_name -> rsca.api.Member
+	22, // 22: rsca.api.PingMessage.ts:type_name -> google.protobuf.Timestamp
+	22, // 23: rsca.api.PongMessage.ts:type_name -> google.protobuf.Timestamp
+	20, // 24: rsca.api.RunCheckResultMessage.event:type_name -> rsca.api.EventMessage
+	7,  // 25: rsca.api.MemberUpdateMessage.member:type_name -> rsca.api.Member
+	1,  // 26: rsca.api.EventMessage.type:type_name -> rsca.api.CheckType
+	0,  // 27: rsca.api.EventMessage.status:type_name -> rsca.api.Status
+	22, // 28: rsca.api.EventMessage.request_timestamp:type_name -> google.protobuf.Timestamp
+	23, // 29: rsca.api.EventMessage.duration:type_name -> google.protobuf.Duration
+	23, // 30: rsca.api.Member.ServicePeriodEntry.value:type_name -> google.protobuf.Duration
+	31, // [31:31] is the sub-list for method output_type
+	31, // [31:31] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_github_com_na4ma4_rsca_api_common_proto_init() }
@@ -2999,6 +3804,10 @@ func file_github_com_na4ma4_rsca_api_common_proto_init() {
 		(*message_TriggerAllMessage)(nil),
 		(*message_RepeatRegistrationMessage)(nil),
 		(*message_MemberUpdateMessage)(nil),
+		(*message_EventAckMessage)(nil),
+		(*message_TriggerCheckMessage)(nil),
+		(*message_RunCheckMessage)(nil),
+		(*message_RunCheckResultMessage)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -3006,7 +3815,7 @@ func file_github_com_na4ma4_rsca_api_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_github_com_na4ma4_rsca_api_common_proto_rawDesc), len(file_github_com_na4ma4_rsca_api_common_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: github.com/na4ma4/rsca/api/local.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	_ "google.golang.org/protobuf/types/gofeaturespb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	mi := &file_github_com_na4ma4_rsca_api_local_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitResponse) ProtoMessage() {}
+
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_na4ma4_rsca_api_local_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SubmitResponse) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SubmitResponse) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *SubmitResponse) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SubmitResponse) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type SubmitResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 SubmitResponse_builder) Build() *SubmitResponse {
+	m0 := &SubmitResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+var File_github_com_na4ma4_rsca_api_local_proto protoreflect.FileDescriptor
+
+const file_github_com_na4ma4_rsca_api_local_proto_rawDesc = "" +
+	"\n" +
+	"&github.com/na4ma4/rsca/api/local.proto\x12\brsca.api\x1a!google/protobuf/go_features.proto\x1a'github.com/na4ma4/rsca/api/common.proto\" \n" +
+	"\x0eSubmitResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2C\n" +
+	"\x05Local\x12:\n" +
+	"\x06Submit\x12\x16.rsca.api.EventMessage\x1a\x18.rsca.api.SubmitResponseB$Z\x1agithub.com/na4ma4/rsca/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
+
+var file_github_com_na4ma4_rsca_api_local_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_na4ma4_rsca_api_local_proto_goTypes = []any{
+	(*SubmitResponse)(nil), // 0: rsca.api.SubmitResponse
+	(*EventMessage)(nil),   // 1: rsca.api.EventMessage
+}
+var file_github_com_na4ma4_rsca_api_local_proto_depIdxs = []int32{
+	1, // 0: rsca.api.Local.Submit:input_type -> rsca.api.EventMessage
+	0, // 1: rsca.api.Local.Submit:output_type -> rsca.api.SubmitResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_github_com_na4ma4_rsca_api_local_proto_init() }
+func file_github_com_na4ma4_rsca_api_local_proto_init() {
+	if File_github_com_na4ma4_rsca_api_local_proto != nil {
+		return
+	}
+	file_github_com_na4ma4_rsca_api_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_github_com_na4ma4_rsca_api_local_proto_rawDesc), len(file_github_com_na4ma4_rsca_api_local_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_github_com_na4ma4_rsca_api_local_proto_goTypes,
+		DependencyIndexes: file_github_com_na4ma4_rsca_api_local_proto_depIdxs,
+		MessageInfos:      file_github_com_na4ma4_rsca_api_local_proto_msgTypes,
+	}.Build()
+	File_github_com_na4ma4_rsca_api_local_proto = out.File
+	file_github_com_na4ma4_rsca_api_local_proto_goTypes = nil
+	file_github_com_na4ma4_rsca_api_local_proto_depIdxs = nil
+}
@@ -0,0 +1,123 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: github.com/na4ma4/rsca/api/local.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Local_Submit_FullMethodName = "/rsca.api.Local/Submit"
+)
+
+// LocalClient is the client API for Local service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Local is served by the client on a local socket for submitting results from the host.
+type LocalClient interface {
+	Submit(ctx context.Context, in *EventMessage, opts ...grpc.CallOption) (*SubmitResponse, error)
+}
+
+type localClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLocalClient(cc grpc.ClientConnInterface) LocalClient {
+	return &localClient{cc}
+}
+
+func (c *localClient) Submit(ctx context.Context, in *EventMessage, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Local_Submit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LocalServer is the server API for Local service.
+// All implementations should embed UnimplementedLocalServer
+// for forward compatibility.
+//
+// Local is served by the client on a local socket for submitting results from the host.
+type LocalServer interface {
+	Submit(context.Context, *EventMessage) (*SubmitResponse, error)
+}
+
+// UnimplementedLocalServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLocalServer struct{}
+
+func (UnimplementedLocalServer) Submit(context.Context, *EventMessage) (*SubmitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedLocalServer) testEmbeddedByValue() {}
+
+// UnsafeLocalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LocalServer will
+// result in compilation errors.
+type UnsafeLocalServer interface {
+	mustEmbedUnimplementedLocalServer()
+}
+
+func RegisterLocalServer(s grpc.ServiceRegistrar, srv LocalServer) {
+	// If the following call pancis, it indicates UnimplementedLocalServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Local_ServiceDesc, srv)
+}
+
+func _Local_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Local_Submit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalServer).Submit(ctx, req.(*EventMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Local_ServiceDesc is the grpc.ServiceDesc for Local service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Local_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rsca.api.Local",
+	HandlerType: (*LocalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Submit",
+			Handler:    _Local_Submit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "github.com/na4ma4/rsca/api/local.proto",
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// unackedEvent is a check result that has been sent but not yet acknowledged by the server.
+type unackedEvent struct {
+	msg  *api.EventMessage
+	sent time.Time
+}
+
+// ackTracker keeps track of check results that are waiting for an api.EventAckMessage.
+type ackTracker struct {
+	pending map[string]*unackedEvent
+	lock    sync.Mutex
+}
+
+func newAckTracker() *ackTracker {
+	return &ackTracker{
+		pending: map[string]*unackedEvent{},
+	}
+}
+
+// Sent records a check result as sent to the server.
+func (a *ackTracker) Sent(msg *api.EventMessage, t time.Time) {
+	if msg.GetId() == "" {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.pending[msg.GetId()] = &unackedEvent{msg: msg, sent: t}
+}
+
+// Ack removes an acknowledged check result, returns false if the id was not waiting for acknowledgement.
+func (a *ackTracker) Ack(id string) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, ok := a.pending[id]; !ok {
+		return false
+	}
+
+	delete(a.pending, id)
+
+	return true
+}
+
+// Expired removes and returns the check results sent before the supplied time.
+func (a *ackTracker) Expired(before time.Time) []*api.EventMessage {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	out := []*api.EventMessage{}
+
+	for id, v := range a.pending {
+		if v.sent.Before(before) {
+			out = append(out, v.msg)
+			delete(a.pending, id)
+		}
+	}
+
+	return out
+}
+
+// processEventAck processes an acknowledgement of a check result from the server.
+func (c *Client) processEventAck(ctx context.Context, msg *api.EventAckMessage) {
+	if c.acks.Ack(msg.GetId()) {
+		c.Logger.DebugContext(ctx, "check result acknowledged", slog.String("response.id", msg.GetId()))
+	}
+}
+
+// RunRetries is a routine that resends check results that have not been acknowledged by the server
+// within `general.retry-timeout`, up to `general.max-retries` times.
+func (c *Client) RunRetries(ctx context.Context, cfg config.Conf) func() error {
+	if !cfg.GetBool("general.retry") {
+		return func() error { return nil }
+	}
+
+	c.retry = true
+	timeout := cfg.GetDuration("general.retry-timeout")
+	maxRetries := cfg.GetInt("general.max-retries")
+	ticker := time.NewTicker(timeout)
+
+	return func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+
+				return nil
+			case t := <-ticker.C:
+				for _, msg := range c.acks.Expired(t.Add(-1 * timeout)) {
+					if int(msg.GetResends()) >= maxRetries {
+						c.Logger.ErrorContext(ctx, "check result was not acknowledged by server, giving up",
+							slog.String("response.id", msg.GetId()),
+							slog.String("check.name", msg.GetCheck()),
+							slog.Int("check.resends", int(msg.GetResends())),
+						)
+
+						continue
+					}
+
+					retry, _ := proto.Clone(msg).(*api.EventMessage)
+					retry.SetResends(msg.GetResends() + 1)
+
+					c.Logger.WarnContext(ctx, "check result was not acknowledged by server, retrying",
+						slog.String("response.id", retry.GetId()),
+						slog.String("check.name", retry.GetCheck()),
+						slog.Int("check.resends", int(retry.GetResends())),
+					)
+
+					c.queueEvent(ctx, retry)
+				}
+			}
+		}
+	}
+}
@@ -3,8 +3,11 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/na4ma4/config"
@@ -13,8 +16,10 @@ import (
 	"github.com/na4ma4/rsca/internal/checks"
 	"github.com/na4ma4/rsca/internal/helpers"
 	"github.com/na4ma4/rsca/internal/register"
+	"github.com/na4ma4/rsca/internal/spool"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // Client is a api.RSCAClient for co-ordinating requests from the server.
@@ -24,94 +29,393 @@ type Client struct {
 	checks   checks.Checks
 	inbox    chan *api.Message
 	outbox   chan *api.Message
+	pending  *api.Message
 	register *register.Message
+	spool    *spool.Spool
+	spooled  chan struct{}
+	acks     *ackTracker
+	retry    bool
+	lock     sync.Mutex
 }
 
+var (
+	// ErrStreamClosed is returned when the server closes the stream.
+	ErrStreamClosed = errors.New("stream closed by server")
+
+	// ErrServerUnavailable is returned when the server can not be reached.
+	ErrServerUnavailable = errors.New("server unavailable")
+
+	// ErrFailback is returned when a session to a secondary server is closed to retry the primary server.
+	ErrFailback = errors.New("failback to primary server")
+
+	// ErrNoTargets is returned when the client has no servers to connect to.
+	ErrNoTargets = errors.New("no servers to connect to")
+)
+
 // NewClient returns a setup api.RSCAClient.
-func NewClient(logger *slog.Logger, hostName string, checkList checks.Checks) *Client {
+func NewClient(
+	logger *slog.Logger,
+	hostName string,
+	checkList checks.Checks,
+	regmsg *register.Message,
+) *Client {
 	return &Client{
 		Logger:   logger,
 		hostname: hostName,
 		checks:   checkList,
 		inbox:    make(chan *api.Message),
 		outbox:   make(chan *api.Message),
+		register: regmsg,
+		spooled:  make(chan struct{}, 1),
+		acks:     newAckTracker(),
+	}
+}
+
+// SetChecks replaces the check list after the configuration has been reloaded.
+func (c *Client) SetChecks(checkList checks.Checks) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.checks = checkList
+}
+
+// GetCheck returns a copy of the configured check with the supplied name, the copy can be run
+// without affecting the scheduled check.
+func (c *Client) GetCheck(name string) (*checks.Info, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	check, ok := c.checks.GetByName(name)
+	if !ok {
+		return nil, false
 	}
+
+	return check.Clone(), true
 }
 
-func (c *Client) streamMessages(ctx context.Context, cancel context.CancelFunc, stream api.RSCA_PipeClient) {
+// SetSpool enables storing check results in a persistent spool until they are sent to the server.
+func (c *Client) SetSpool(sp *spool.Spool) {
+	c.spool = sp
+}
+
+func (c *Client) streamMessages(
+	ctx context.Context,
+	stream api.RSCA_PipeClient,
+	errChan chan<- error,
+	received *atomic.Bool,
+) {
 	for {
 		in, err := stream.Recv()
 
 		if errors.Is(err, io.EOF) {
-			c.Logger.DebugContext(ctx, "EOF found, closing channel")
-			cancel()
+			c.Logger.DebugContext(ctx, "EOF found, closing stream")
+			errChan <- ErrStreamClosed
 
 			return
-		} else if s, ok := status.FromError(err); err != nil && ok {
-			if s.Code() == codes.Unavailable {
-				c.Logger.WarnContext(ctx, "server has gone away", slogtool.ErrorAttr(err))
-				cancel()
+		} else if s, ok := status.FromError(err); err != nil && ok && s.Code() == codes.Unavailable {
+			c.Logger.WarnContext(ctx, "server has gone away", slogtool.ErrorAttr(err))
+			errChan <- fmt.Errorf("%w: %w", ErrServerUnavailable, err)
 
-				return
-			}
+			return
 		} else if err != nil {
 			c.Logger.ErrorContext(ctx, "failed to receive a note", slog.Any("msg", in), slogtool.ErrorAttr(err))
-			cancel()
+			errChan <- fmt.Errorf("failed to receive message: %w", err)
 
 			return
 		}
 
-		if ctx.Err() != nil {
+		received.Store(true)
+
+		select {
+		case c.inbox <- in:
+		case <-ctx.Done():
 			return
 		}
-
-		c.inbox <- in
 	}
 }
 
-// Pipe processes the stream and the outbox back to the server.
+// Pipe maintains the stream to the server and processes the outbox.
+//
+// When the stream is lost (or registration fails) the client fails over to the next target in the
+// list, once every target has been tried it waits for an exponential backoff with jitter before
+// starting again. The backoff is only reset by a stream that received a message from the server, so
+// a server that accepts the registration and then closes the stream does not cause a tight loop.
+// If `client.failback` is enabled a client connected to a secondary target will periodically
+// attempt to return to the primary target.
+//
+// ErrNoTargets is returned when the list of targets is empty.
 func (c *Client) Pipe(
 	ctx context.Context,
-	cancel context.CancelFunc,
 	cfg config.Conf,
-	stream api.RSCA_PipeClient,
+	targets []*Target,
 ) func() error {
-	registrationTicker := time.NewTicker(cfg.GetDuration("general.registration-interval"))
-
-	go c.streamMessages(ctx, cancel, stream)
+	backoff := helpers.NewBackoff(
+		cfg.GetDuration("client.reconnect.min-backoff"),
+		cfg.GetDuration("client.reconnect.max-backoff"),
+	)
 
 	return func() error {
+		if len(targets) == 0 {
+			return ErrNoTargets
+		}
+
+		active, failed := 0, 0
+
 		for {
+			healthy, err := c.runSession(ctx, cfg, active, targets[active])
+			if ctx.Err() != nil {
+				c.Logger.DebugContext(ctx, "context cancelled")
+
+				return nil
+			}
+
+			if healthy {
+				backoff.Reset()
+
+				failed = 0
+			}
+
+			if errors.Is(err, ErrFailback) {
+				c.Logger.InfoContext(ctx, "failing back to primary server",
+					slog.String("server", targets[0].Address),
+				)
+
+				active = 0
+
+				continue
+			}
+
+			failed++
+			active = (active + 1) % len(targets)
+
+			if failed < len(targets) {
+				c.Logger.WarnContext(ctx, "stream to server lost, failing over",
+					slog.String("server", targets[active].Address),
+					slogtool.ErrorAttr(err),
+				)
+
+				continue
+			}
+
+			failed = 0
+			delay := backoff.Next()
+			c.Logger.WarnContext(ctx, "stream to server lost, reconnecting",
+				slog.String("server", targets[active].Address),
+				slog.Duration("delay", delay),
+				slogtool.ErrorAttr(err),
+			)
+
 			select {
 			case <-ctx.Done():
 				c.Logger.DebugContext(ctx, "context cancelled")
-				registrationTicker.Stop()
-				close(c.outbox)
-				close(c.inbox)
 
 				return nil
-			case _, ok := <-registrationTicker.C:
-				if ok {
-					go c.SendRepeatRegistration(ctx)
-				}
-			case out, ok := <-c.outbox:
-				if ok {
-					if err := stream.Send(out); err != nil {
-						c.Logger.ErrorContext(ctx, "unable to send message", slogtool.ErrorAttr(err))
-						cancel()
-					}
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// runSession opens a single stream to the server, registers and then processes the outbox
+// until the stream fails or the context is cancelled, it returns true if the server sent a message
+// on the stream.
+//
+//nolint:gocognit // I don't see an easy way to make this less complex without making it less maintainable.
+func (c *Client) runSession(
+	ctx context.Context,
+	cfg config.Conf,
+	index int,
+	target *Target,
+) (bool, error) {
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := target.Client.Pipe(sctx)
+	if err != nil {
+		return false, fmt.Errorf("unable to create stream to %s: %w", target.Address, err)
+	}
+
+	c.register.SetServer(target.Address)
+
+	if err = stream.Send(c.registerMessage()); err != nil {
+		return false, fmt.Errorf("unable to register with %s: %w", target.Address, err)
+	}
+
+	c.Logger.InfoContext(ctx, "registered with server", slog.String("server", target.Address))
+
+	var received atomic.Bool
+
+	errChan := make(chan error, 1)
+	go c.streamMessages(sctx, stream, errChan, &received)
+
+	registrationTicker := time.NewTicker(cfg.GetDuration("general.registration-interval"))
+	defer registrationTicker.Stop()
+
+	var failback <-chan time.Time
+
+	if index > 0 && cfg.GetBool("client.failback") {
+		failbackTimer := time.NewTimer(cfg.GetDuration("client.failback-interval"))
+		defer failbackTimer.Stop()
+
+		failback = failbackTimer.C
+	}
+
+	if c.pending != nil {
+		if err = stream.Send(c.pending); err != nil {
+			return received.Load(), fmt.Errorf("unable to resend message: %w", err)
+		}
+
+		c.eventSent(c.pending.GetEventMessage())
+		c.pending = nil
+	}
+
+	if err = c.drainSpool(ctx, stream); err != nil {
+		return received.Load(), err
+	}
+
+	for {
+		select {
+		case <-sctx.Done():
+			_ = stream.CloseSend()
+
+			return received.Load(), nil
+		case <-failback:
+			_ = stream.CloseSend()
+
+			return received.Load(), ErrFailback
+		case err = <-errChan:
+			return received.Load(), err
+		case <-registrationTicker.C:
+			go c.SendRepeatRegistration(sctx)
+		case <-c.spooled:
+			if err = c.drainSpool(ctx, stream); err != nil {
+				return received.Load(), err
+			}
+		case out := <-c.outbox:
+			if err = stream.Send(out); err != nil {
+				if out.WhichMessage() == api.Message_EventMessage_case {
+					// keep the failed event to be sent first on the next stream.
+					c.pending = out
 				}
+
+				return received.Load(), fmt.Errorf("unable to send message: %w", err)
+			}
+
+			c.eventSent(out.GetEventMessage())
+		}
+	}
+}
+
+func (c *Client) registerMessage() *api.Message {
+	return api.Message_builder{
+		Envelope:        api.Envelope_builder{Sender: c.register.Member(), Recipient: api.MembersByID("_server")}.Build(),
+		RegisterMessage: c.register.Message(),
+	}.Build()
+}
+
+// drainSpool sends all spooled check results to the server in the order they were produced.
+func (c *Client) drainSpool(ctx context.Context, stream api.RSCA_PipeClient) error {
+	if c.spool == nil {
+		return nil
+	}
+
+	count := 0
+
+	if err := c.spool.Walk(func(in *api.EventMessage) error {
+		if err := stream.Send(c.wrapEventMessage(in)); err != nil {
+			return fmt.Errorf("unable to send spooled message: %w", err)
+		}
+
+		c.eventSent(in)
+		count++
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	c.Logger.DebugContext(ctx, "spooled check results sent", slog.Int("count", count))
+
+	return nil
+}
+
+// eventSent records a check result as waiting for acknowledgement from the server.
+func (c *Client) eventSent(in *api.EventMessage) {
+	if c.retry && in != nil {
+		c.acks.Sent(in, time.Now())
+	}
+}
+
+// queueEvent queues a check result to be sent to the server, via the spool if it is enabled.
+func (c *Client) queueEvent(ctx context.Context, in *api.EventMessage) {
+	if c.spool != nil {
+		err := c.spool.Put(in)
+		if err == nil {
+			select {
+			case c.spooled <- struct{}{}:
+			default:
 			}
+
+			return
 		}
+
+		c.Logger.ErrorContext(ctx, "unable to spool check result", slogtool.ErrorAttr(err))
+	}
+
+	c.send(ctx, c.wrapEventMessage(in))
+}
+
+// send adds a message to the outbox, it blocks until the message is taken or the context is cancelled.
+func (c *Client) send(ctx context.Context, msg *api.Message) {
+	select {
+	case c.outbox <- msg:
+	case <-ctx.Done():
 	}
 }
 
 // processUpdateAll processes a trigger all message.
 func (c *Client) processUpdateAll(ctx context.Context) {
 	c.Logger.DebugContext(ctx, "processUpdateAll() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.checks.NextRun(time.Time{})
 }
 
+// processTriggerCheck processes a request to re-run specific checks.
+func (c *Client) processTriggerCheck(ctx context.Context, msg *api.TriggerCheckMessage) {
+	c.Logger.DebugContext(ctx, "processTriggerCheck() called", slog.Any("checks", msg.GetChecks()))
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	triggered := c.checks.NextRunByName(time.Time{}, msg.GetChecks())
+	c.Logger.InfoContext(ctx, "checks triggered by server", slog.Any("checks", triggered))
+}
+
+// processRunCheck runs a check on demand and replies to the server with the result, the scheduled
+// check is not affected.
+func (c *Client) processRunCheck(ctx context.Context, in *api.Message, msg *api.RunCheckMessage) {
+	c.Logger.DebugContext(ctx, "processRunCheck() called", slog.String("check.name", msg.GetCheck()))
+
+	result := api.RunCheckResultMessage_builder{
+		Id: proto.String(msg.GetId()),
+	}.Build()
+
+	if check, ok := c.GetCheck(msg.GetCheck()); ok {
+		result.SetEvent(check.Run(ctx, time.Now()))
+	} else {
+		result.SetError(fmt.Sprintf("check not found: %s", msg.GetCheck()))
+	}
+
+	c.send(ctx, api.Message_builder{
+		Envelope: api.Envelope_builder{
+			Sender:    c.register.Member(),
+			Recipient: api.RecipientBySender(in.GetEnvelope().GetSender()),
+		}.Build(),
+		RunCheckResultMessage: result,
+	}.Build())
+}
+
 // processRepeatRegister processes a repeat-registration request message.
 func (c *Client) processRepeatRegister(ctx context.Context) {
 	c.Logger.DebugContext(ctx, "processRepeatRegister() called")
@@ -123,10 +427,10 @@ func (c *Client) SendRepeatRegistration(ctx context.Context) {
 	c.Logger.DebugContext(ctx, "sending repeat registration message")
 	c.register.UpdateInfoStat(ctx)
 
-	c.outbox <- api.Message_builder{
+	c.send(ctx, api.Message_builder{
 		Envelope:            api.Envelope_builder{Sender: c.register.Member(), Recipient: api.MembersByID("_server")}.Build(),
 		MemberUpdateMessage: c.register.UpdateMessage(),
-	}.Build()
+	}.Build())
 }
 
 // // Send adds a message to the outbox to be sent, may block if channel is full.
@@ -145,16 +449,10 @@ func (c *Client) wrapEventMessage(in *api.EventMessage) *api.Message {
 }
 
 // RunEvents runs as a go routine that processes the response channel and creates messages to add to the outbox.
-//
-//nolint:gocognit // I don't see an easy way to make this less complex without making it less maintainable.
 func (c *Client) RunEvents(
 	ctx context.Context,
-	cancel context.CancelFunc,
-	regmsg *register.Message,
 	respChan chan *api.EventMessage,
 ) func() error {
-	c.register = regmsg
-
 	return func() error {
 		for {
 			select {
@@ -164,33 +462,38 @@ func (c *Client) RunEvents(
 				return nil
 			case in, ok := <-respChan:
 				if ok {
-					c.outbox <- c.wrapEventMessage(in)
+					c.queueEvent(ctx, in)
 				}
 			case in, ok := <-c.inbox:
-				if ok {
-					if in != nil {
-						switch v := in.WhichMessage(); v { //nolint:exhaustive // default catches unhandled.
-						case api.Message_PingMessage_case:
-							go func() {
-								c.outbox <- helpers.GeneratePingMessage(ctx, c.Logger, c.hostname, in, in.GetPingMessage())
-							}()
-						case api.Message_TriggerAllMessage_case:
-							go c.processUpdateAll(ctx)
-						case api.Message_RepeatRegistrationMessage_case:
-							go c.processRepeatRegister(ctx)
-						default:
-							c.Logger.InfoContext(ctx,
-								"Received unhandled message",
-								slog.String("message-type", v.String()),
-							)
-						}
-						c.Logger.DebugContext(ctx, "message processing finished")
-					} else {
-						c.Logger.DebugContext(ctx, "nil message received")
-						cancel()
-					}
+				if ok && in != nil {
+					c.processMessage(ctx, in)
 				}
 			}
 		}
 	}
 }
+
+// processMessage dispatches a message received from the server.
+func (c *Client) processMessage(ctx context.Context, in *api.Message) {
+	switch v := in.WhichMessage(); v { //nolint:exhaustive // default catches unhandled.
+	case api.Message_PingMessage_case:
+		go c.send(ctx, helpers.GeneratePingMessage(ctx, c.Logger, c.hostname, in, in.GetPingMessage()))
+	case api.Message_TriggerAllMessage_case:
+		go c.processUpdateAll(ctx)
+	case api.Message_TriggerCheckMessage_case:
+		go c.processTriggerCheck(ctx, in.GetTriggerCheckMessage())
+	case api.Message_RunCheckMessage_case:
+		go c.processRunCheck(ctx, in, in.GetRunCheckMessage())
+	case api.Message_RepeatRegistrationMessage_case:
+		go c.processRepeatRegister(ctx)
+	case api.Message_EventAckMessage_case:
+		c.processEventAck(ctx, in.GetEventAckMessage())
+	default:
+		c.Logger.InfoContext(ctx,
+			"Received unhandled message",
+			slog.String("message-type", v.String()),
+		)
+	}
+
+	c.Logger.DebugContext(ctx, "message processing finished")
+}
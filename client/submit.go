@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SubmitServer is a api.LocalServer that accepts check results from processes on the local host
+// (eg. cron jobs) and sends them to the server through the client stream.
+type SubmitServer struct {
+	Logger   *slog.Logger
+	hostname string
+	allowed  []string
+	respChan chan<- *api.EventMessage
+}
+
+// NewSubmitServer returns a SubmitServer that accepts results for the local hostname and any
+// hostnames in allowed, a `*` in allowed accepts results for any hostname.
+func NewSubmitServer(
+	logger *slog.Logger,
+	hostName string,
+	allowed []string,
+	respChan chan<- *api.EventMessage,
+) *SubmitServer {
+	return &SubmitServer{
+		Logger:   logger,
+		hostname: hostName,
+		allowed:  allowed,
+		respChan: respChan,
+	}
+}
+
+// isAllowed returns true if results can be submitted for the hostname.
+func (s *SubmitServer) isAllowed(hostName string) bool {
+	if strings.EqualFold(hostName, s.hostname) || slices.Contains(s.allowed, "*") {
+		return true
+	}
+
+	return slices.ContainsFunc(s.allowed, func(v string) bool { return strings.EqualFold(v, hostName) })
+}
+
+// Submit queues a check result to be sent to the server.
+func (s *SubmitServer) Submit(ctx context.Context, in *api.EventMessage) (*api.SubmitResponse, error) {
+	msg, _ := proto.Clone(in).(*api.EventMessage)
+
+	if msg.GetHostname() == "" {
+		msg.SetHostname(s.hostname)
+	}
+
+	if !s.isAllowed(msg.GetHostname()) {
+		return nil, status.Errorf(codes.PermissionDenied, "results for host '%s' are not accepted", msg.GetHostname())
+	}
+
+	if msg.GetType() == api.CheckType_SERVICE && msg.GetCheck() == "" {
+		return nil, status.Error(codes.InvalidArgument, "check name is required for service results")
+	}
+
+	if _, ok := api.Status_name[int32(msg.GetStatus())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid status: %d", msg.GetStatus())
+	}
+
+	msg.SetId(uuid.New().String())
+
+	if !msg.HasRequestTimestamp() {
+		msg.SetRequestTimestamp(timestamppb.Now())
+	}
+
+	s.Logger.InfoContext(ctx, "received submitted check result",
+		slog.String("response.id", msg.GetId()),
+		slog.String("check.hostname", msg.GetHostname()),
+		slog.String("check.name", msg.GetCheck()),
+		slog.String("check.status", msg.GetStatus().String()),
+	)
+
+	select {
+	case s.respChan <- msg:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return api.SubmitResponse_builder{
+		Id: proto.String(msg.GetId()),
+	}.Build(), nil
+}
+
+// Run is a routine that serves the SubmitServer on a unix socket at path until the context is cancelled.
+func (s *SubmitServer) Run(ctx context.Context, path string) func() error {
+	return func() error {
+		if err := os.MkdirAll(filepath.Dir(path), permbits.MustString("u=rwx,go=rx")); err != nil {
+			return fmt.Errorf("unable to create submit socket directory: %w", err)
+		}
+
+		lis, err := helpers.Listen(ctx, "unix:"+path)
+		if err != nil {
+			return fmt.Errorf("unable to listen on submit socket: %w", err)
+		}
+
+		gs := grpc.NewServer()
+		api.RegisterLocalServer(gs, s)
+
+		go func() {
+			<-ctx.Done()
+			gs.Stop()
+		}()
+
+		s.Logger.InfoContext(ctx, "listening for submitted check results", slog.String("socket", path))
+
+		if err = gs.Serve(lis); err != nil {
+			return fmt.Errorf("submit socket server failed: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,10 @@
+package client
+
+import "github.com/na4ma4/rsca/api"
+
+// Target is a server the client can connect to, targets are tried in order.
+type Target struct {
+	Address string
+	SNI     string
+	Client  api.RSCAClient
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var cmdAudit = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit Log Commands",
+}
+
+func init() {
+	rootCmd.AddCommand(cmdAudit)
+}
+
+func printAuditList(
+	ctx context.Context,
+	logger *slog.Logger,
+	tmpl *template.Template,
+	auditList []*model.AuditEntry,
+) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd // ignore padding count.
+
+	if !strings.Contains(tmpl.Root.String(), "json") {
+		if err := tmpl.Execute(w, map[string]interface{}{
+			"Timestamp":     "Timestamp",
+			"Method":        "Method",
+			"Identity":      "Identity",
+			"Fingerprint":   "Fingerprint",
+			"PeerAddress":   "Peer Address",
+			"Targets":       "Targets",
+			"AffectedHosts": "Affected Hosts",
+			"Outcome":       "Outcome",
+			"Error":         "Error",
+			"Duration":      "Duration",
+		}); err != nil {
+			logger.ErrorContext(ctx, "error parsing template", slogtool.ErrorAttr(err))
+		}
+	}
+
+	for _, in := range auditList {
+		if err := tmpl.Execute(w, in); err != nil {
+			logger.ErrorContext(ctx, "error displaying audit entry", slogtool.ErrorAttr(err))
+		}
+	}
+
+	_ = w.Flush()
+}
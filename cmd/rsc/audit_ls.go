@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var cmdAuditList = &cobra.Command{
+	Use:   "ls",
+	Short: "List recent administrative requests from the server audit log",
+	Args:  cobra.NoArgs,
+	Run:   auditListCommand,
+}
+
+func init() {
+	cmdAuditList.PersistentFlags().Duration("since", 24*time.Hour, //nolint:mnd // one day.
+		"Only list requests made within the duration (0 for all requests)",
+	)
+	cmdAuditList.PersistentFlags().IntP("limit", "n", 100, //nolint:mnd // default entry count.
+		"Maximum number of most recent requests to list (0 for all requests)",
+	)
+	cmdAuditList.PersistentFlags().StringP("format", "f",
+		"{{time .Timestamp}}\t{{.Method}}\t{{.Identity}}\t{{.PeerAddress}}\t{{.Outcome}}\t{{.Targets}}\t{{.AffectedHosts}}",
+		"Output format (go template)",
+	)
+
+	_ = viper.BindPFlag("audit.list.since", cmdAuditList.PersistentFlags().Lookup("since"))
+	_ = viper.BindPFlag("audit.list.limit", cmdAuditList.PersistentFlags().Lookup("limit"))
+	_ = viper.BindPFlag("audit.list.format", cmdAuditList.PersistentFlags().Lookup("format"))
+
+	cmdAudit.AddCommand(cmdAuditList)
+}
+
+func auditListCommand(_ *cobra.Command, _ []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	logLevel := slog.LevelInfo
+	if cfg.GetBool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	_, logger := helpers.LogManager(logLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpl, err := parseFormat(cfg.GetString("audit.list.format"))
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to load template engine", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	req := api.ListAuditRequest_builder{}.Build()
+	req.SetLimit(int32(cfg.GetInt("audit.list.limit"))) //nolint:gosec // limit is a small count.
+
+	if since := cfg.GetDuration("audit.list.since"); since > 0 {
+		req.SetSince(timestamppb.New(time.Now().Add(-since)))
+	}
+
+	gc := dialGRPC(ctx, cfg, logger)
+
+	cc := api.NewAdminClient(gc)
+
+	stream, err := cc.ListAudit(ctx, req)
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to receive ListAudit stream from server", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	auditList := []*model.AuditEntry{}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			logger.ErrorContext(ctx, "unable to receive audit entries from server", slogtool.ErrorAttr(err))
+			return
+		}
+
+		auditList = append(auditList, model.AuditEntryFromAPI(in))
+	}
+
+	printAuditList(ctx, logger, tmpl, auditList)
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cmdCheck = &cobra.Command{
+	Use:     "check",
+	Aliases: []string{"c"},
+	Short:   "Check Commands",
+}
+
+func init() {
+	rootCmd.AddCommand(cmdCheck)
+}
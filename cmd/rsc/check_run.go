@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+)
+
+var cmdCheckRun = &cobra.Command{
+	Use:   "run <host> <check>",
+	Short: "Run a check on a host and display the result",
+	Run:   checkRunCommand,
+	Args:  cobra.ExactArgs(2), //nolint:mnd // host and check.
+}
+
+func init() {
+	cmdCheckRun.PersistentFlags().Bool("forward", false,
+		"forward the result to nagios as well as displaying it",
+	)
+	cmdCheckRun.PersistentFlags().Duration("timeout", time.Minute,
+		"how long to wait for the check result",
+	)
+
+	_ = viper.BindPFlag("check.run.forward", cmdCheckRun.PersistentFlags().Lookup("forward"))
+	_ = viper.BindPFlag("check.run.timeout", cmdCheckRun.PersistentFlags().Lookup("timeout"))
+
+	cmdCheck.AddCommand(cmdCheckRun)
+}
+
+//nolint:forbidigo // Display Function
+func checkRunCommand(_ *cobra.Command, args []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	_, logger := helpers.LogManager(slog.LevelInfo)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetDuration("check.run.timeout"))
+	defer cancel()
+
+	gc := dialGRPC(ctx, cfg, logger)
+	cc := api.NewAdminClient(gc)
+
+	r, err := cc.RunCheck(ctx, api.RunCheckRequest_builder{
+		Hostname: proto.String(args[0]),
+		Check:    proto.String(args[1]),
+		Forward:  proto.Bool(cfg.GetBool("check.run.forward")),
+	}.Build())
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to run check", slogtool.ErrorAttr(err))
+
+		return
+	}
+
+	ev := r.GetEvent()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd // ignore padding count.
+	fmt.Fprintf(w, "Host:\t%s\n", ev.GetHostname())
+	fmt.Fprintf(w, "Check:\t%s\n", ev.GetCheck())
+	fmt.Fprintf(w, "Status:\t%s (%d)\n", ev.GetStatus(), ev.GetStatus())
+	fmt.Fprintf(w, "Duration:\t%s\n", ev.GetDuration().AsDuration())
+	fmt.Fprintf(w, "Output:\t%s\n", ev.GetOutput())
+
+	if ev.GetLongOutput() != "" {
+		fmt.Fprintf(w, "Long Output:\t%s\n", ev.GetLongOutput())
+	}
+
+	if ev.GetPerfdata() != "" {
+		fmt.Fprintf(w, "Perfdata:\t%s\n", ev.GetPerfdata())
+	}
+
+	if ev.GetOutputError() != "" {
+		fmt.Fprintf(w, "Stderr:\t%s\n", ev.GetOutputError())
+	}
+
+	_ = w.Flush()
+}
@@ -74,6 +74,7 @@ func printHostList(
 			"Latency":      "Latency",
 			"Name":         "Name",
 			"Active":       "Active",
+			"Server":       "Server",
 			"PingLatency":  "Ping Latency",
 			"SystemStart":  "System Start",
 			"ProcessStart": "Process Start",
@@ -26,8 +26,8 @@ var cmdHostList = &cobra.Command{
 
 func init() {
 	cmdHostList.PersistentFlags().StringP("format", "f",
-		"{{.Name}}\t{{.Active}}\t{{time .LastSeen}}\t{{age .LastSeen}}\t{{.Tag}}\t{{.Capability}}\t{{age .SystemStart}}"+
-			"\t{{.Service}}",
+		"{{.Name}}\t{{.Active}}\t{{.Server}}\t{{time .LastSeen}}\t{{age .LastSeen}}\t{{.Tag}}\t{{.Capability}}"+
+			"\t{{age .SystemStart}}\t{{.Service}}",
 		"Output format (go template)",
 	)
 
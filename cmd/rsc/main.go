@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"strings"
 
 	"github.com/na4ma4/config"
 	"github.com/na4ma4/go-certprovider"
@@ -32,6 +33,10 @@ func main() {
 }
 
 func grpcServer(server string) string {
+	if strings.HasPrefix(server, "unix:") {
+		return server
+	}
+
 	host, port, err := net.SplitHostPort(server)
 	if err != nil {
 		return server + ":5888"
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var cmdResult = &cobra.Command{
+	Use:     "result",
+	Aliases: []string{"r"},
+	Short:   "Check Result Commands",
+}
+
+func init() {
+	rootCmd.AddCommand(cmdResult)
+}
+
+func printResultList(
+	ctx context.Context,
+	logger *slog.Logger,
+	tmpl *template.Template,
+	resultList []*model.Result,
+) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd // ignore padding count.
+
+	if !strings.Contains(tmpl.Root.String(), "json") {
+		if err := tmpl.Execute(w, map[string]interface{}{
+			"ID":         "ID",
+			"Hostname":   "Host Name",
+			"Type":       "Type",
+			"Check":      "Check",
+			"Status":     "Status",
+			"Output":     "Output",
+			"LongOutput": "Long Output",
+			"Perfdata":   "Perfdata",
+			"Timestamp":  "Timestamp",
+			"Duration":   "Duration",
+			"Retries":    "Retries",
+			"MaxRetries": "Max Retries",
+			"Soft":       "Soft",
+		}); err != nil {
+			logger.ErrorContext(ctx, "error parsing template", slogtool.ErrorAttr(err))
+		}
+	}
+
+	for _, in := range resultList {
+		if err := tmpl.Execute(w, in); err != nil {
+			logger.ErrorContext(ctx, "error displaying result", slogtool.ErrorAttr(err))
+		}
+	}
+
+	_ = w.Flush()
+}
+
+// sortResultList sorts results by hostname and then check.
+func sortResultList(resultList []*model.Result) {
+	sort.SliceStable(resultList, func(i, j int) bool {
+		if resultList[i].Hostname != resultList[j].Hostname {
+			return resultList[i].Hostname < resultList[j].Hostname
+		}
+
+		return resultList[i].Check < resultList[j].Check
+	})
+}
+
+// parseFormat returns the go template from the format, `\t` is replaced with a tab and a newline is appended.
+func parseFormat(format string) (*template.Template, error) {
+	format = strings.ReplaceAll(format, "\\t", "\t")
+
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+
+	return template.New("").Funcs(basicFunctions()).Parse(format) //nolint:wrapcheck // caller logs error.
+}
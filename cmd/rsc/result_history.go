@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var cmdResultHistory = &cobra.Command{
+	Use:   "history <hostname> <check>",
+	Short: "List the stored check results of a host and check",
+	Args:  cobra.ExactArgs(2), //nolint:mnd // hostname and check.
+	Run:   resultHistoryCommand,
+}
+
+func init() {
+	cmdResultHistory.PersistentFlags().Duration("since", 24*time.Hour, //nolint:mnd // one day.
+		"Only list results run within the duration (0 for all results)",
+	)
+	cmdResultHistory.PersistentFlags().Duration("until", 0,
+		"Only list results run before the duration ago (0 for now)",
+	)
+	cmdResultHistory.PersistentFlags().StringP("format", "f",
+		"{{time .Timestamp}}\t{{.Status}}\t{{.Soft}}\t{{.Duration}}\t{{.Output}}",
+		"Output format (go template)",
+	)
+
+	_ = viper.BindPFlag("result.history.since", cmdResultHistory.PersistentFlags().Lookup("since"))
+	_ = viper.BindPFlag("result.history.until", cmdResultHistory.PersistentFlags().Lookup("until"))
+	_ = viper.BindPFlag("result.history.format", cmdResultHistory.PersistentFlags().Lookup("format"))
+
+	cmdResult.AddCommand(cmdResultHistory)
+}
+
+func resultHistoryCommand(_ *cobra.Command, args []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	logLevel := slog.LevelInfo
+	if cfg.GetBool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	_, logger := helpers.LogManager(logLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpl, err := parseFormat(cfg.GetString("result.history.format"))
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to load template engine", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	req := api.ResultHistoryRequest_builder{
+		Hostname: &args[0],
+		Check:    &args[1],
+	}.Build()
+
+	now := time.Now()
+
+	if since := cfg.GetDuration("result.history.since"); since > 0 {
+		req.SetSince(timestamppb.New(now.Add(-since)))
+	}
+
+	if until := cfg.GetDuration("result.history.until"); until > 0 {
+		req.SetUntil(timestamppb.New(now.Add(-until)))
+	}
+
+	gc := dialGRPC(ctx, cfg, logger)
+
+	cc := api.NewAdminClient(gc)
+
+	resp, err := cc.GetResultHistory(ctx, req)
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to retrieve result history from server", slogtool.ErrorAttr(err))
+		return
+	}
+
+	resultList := make([]*model.Result, 0, len(resp.GetEvents()))
+	for _, in := range resp.GetEvents() {
+		resultList = append(resultList, model.ResultFromAPI(in))
+	}
+
+	printResultList(ctx, logger, tmpl, resultList)
+}
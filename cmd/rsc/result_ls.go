@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// errInvalidStatus is returned when a status filter is not a check status.
+var errInvalidStatus = errors.New("invalid status")
+
+var cmdResultList = &cobra.Command{
+	Use:   "ls [hostname...]",
+	Short: "List the latest check result of each host and check",
+	Run:   resultListCommand,
+}
+
+func init() {
+	cmdResultList.PersistentFlags().StringSliceP("check", "k", []string{}, "Only list results of the checks")
+	cmdResultList.PersistentFlags().StringSliceP("status", "s", []string{},
+		"Only list results with the statuses (ok, warning, critical, unknown)",
+	)
+	cmdResultList.PersistentFlags().StringP("format", "f",
+		"{{.Hostname}}\t{{.Check}}\t{{.Status}}\t{{time .Timestamp}}\t{{age .Timestamp}}\t{{.Duration}}\t{{.Output}}",
+		"Output format (go template)",
+	)
+
+	_ = viper.BindPFlag("result.list.check", cmdResultList.PersistentFlags().Lookup("check"))
+	_ = viper.BindPFlag("result.list.status", cmdResultList.PersistentFlags().Lookup("status"))
+	_ = viper.BindPFlag("result.list.format", cmdResultList.PersistentFlags().Lookup("format"))
+
+	cmdResult.AddCommand(cmdResultList)
+}
+
+func resultListCommand(_ *cobra.Command, args []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	logLevel := slog.LevelInfo
+	if cfg.GetBool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	_, logger := helpers.LogManager(logLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statuses, err := parseStatuses(cfg.GetStringSlice("result.list.status"))
+	if err != nil {
+		logger.ErrorContext(ctx, "invalid status filter", slogtool.ErrorAttr(err))
+		return
+	}
+
+	tmpl, err := parseFormat(cfg.GetString("result.list.format"))
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to load template engine", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	gc := dialGRPC(ctx, cfg, logger)
+
+	cc := api.NewAdminClient(gc)
+
+	stream, err := cc.ListResults(ctx, api.ListResultsRequest_builder{
+		Hostnames: args,
+		Checks:    cfg.GetStringSlice("result.list.check"),
+		Statuses:  statuses,
+	}.Build())
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to receive ListResults stream from server", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	resultList := []*model.Result{}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			logger.ErrorContext(ctx, "unable to receive results from server", slogtool.ErrorAttr(err))
+			return
+		}
+
+		resultList = append(resultList, model.ResultFromAPI(in))
+	}
+
+	sortResultList(resultList)
+	printResultList(ctx, logger, tmpl, resultList)
+}
+
+// parseStatuses converts status names into statuses.
+func parseStatuses(in []string) ([]api.Status, error) {
+	out := []api.Status{}
+
+	for _, v := range in {
+		st, ok := api.Status_value[strings.ToUpper(v)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errInvalidStatus, v)
+		}
+
+		out = append(out, api.Status(st))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cmdTriggerCheck = &cobra.Command{
+	Use:     "check [options ...] [host...] [hostN]",
+	Aliases: []string{"c"},
+	Short:   "Trigger specific checks on a host",
+	Run:     triggerCheckCommand,
+	Args:    cobra.MinimumNArgs(0),
+}
+
+func init() {
+	cmdTrigger.AddCommand(cmdTriggerCheck)
+	cmdTriggerCheck.PersistentFlags().StringSliceP("check", "k", []string{},
+		"checks to trigger, specified argument repeatedly to trigger multiple checks",
+	)
+	cmdTriggerCheck.PersistentFlags().StringSliceP("tags", "t", []string{},
+		"tags to target, OR'd list, specified argument repeatedly to target multiple tags",
+	)
+	cmdTriggerCheck.PersistentFlags().StringSliceP("capabilities", "c", []string{},
+		"capabilities to target, OR'd list, specified argument repeatedly to target multiple capabilities",
+	)
+
+	_ = viper.BindPFlag("trigger.check.checks", cmdTriggerCheck.PersistentFlags().Lookup("check"))
+	_ = viper.BindPFlag("trigger.check.tags", cmdTriggerCheck.PersistentFlags().Lookup("tags"))
+	_ = viper.BindPFlag("trigger.check.capabilities", cmdTriggerCheck.PersistentFlags().Lookup("capabilities"))
+}
+
+//nolint:forbidigo // Display Function
+func triggerCheckCommand(_ *cobra.Command, args []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	_, logger := helpers.LogManager(slog.LevelInfo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if len(cfg.GetStringSlice("trigger.check.checks")) == 0 {
+		fmt.Println("No checks specified, use --check to specify the checks to trigger")
+
+		return
+	}
+
+	gc := dialGRPC(ctx, cfg, logger)
+	cc := api.NewAdminClient(gc)
+
+	ms := api.Members_builder{
+		Tag:        cfg.GetStringSlice("trigger.check.tags"),
+		Capability: cfg.GetStringSlice("trigger.check.capabilities"),
+	}.Build()
+
+	if len(args) > 0 {
+		ms.SetName(args)
+	}
+
+	r, reqErr := cc.TriggerCheck(ctx, api.TriggerCheckRequest_builder{
+		Members: ms,
+		Checks:  cfg.GetStringSlice("trigger.check.checks"),
+	}.Build())
+	if reqErr != nil {
+		logger.ErrorContext(ctx, "unable to trigger checks", slogtool.ErrorAttr(reqErr))
+		panic(reqErr)
+	}
+
+	fmt.Printf("Trigger message sent for %d checks\n", len(r.GetChecks()))
+
+	for _, c := range r.GetChecks() {
+		fmt.Printf("%s\t%s\n", c.GetHostname(), c.GetCheck())
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var cmdWatch = &cobra.Command{
+	Use:   "watch [hostname...]",
+	Short: "Show check results and host events as they happen",
+	Long: "Show check results and host events (register, update, disconnect, reaped, removed) as they happen,\n" +
+		"hostnames can be patterns (eg. web*).",
+	Run: watchCommand,
+}
+
+func init() {
+	cmdWatch.PersistentFlags().StringSliceP("tag", "t", []string{}, "Only show events from hosts with any of the tags")
+	cmdWatch.PersistentFlags().StringSliceP("check", "k", []string{}, "Only show results of the checks")
+	cmdWatch.PersistentFlags().StringSliceP("status", "s", []string{},
+		"Only show results with the statuses (ok, warning, critical, unknown)",
+	)
+	cmdWatch.PersistentFlags().StringP("format", "f",
+		"{{time .Timestamp}} {{padlen .Type 10}} {{padlen .Hostname 20}} {{padlen .Check 20}} {{padlen .Status 8}} "+
+			"{{.Output}}",
+		"Output format (go template)",
+	)
+
+	_ = viper.BindPFlag("watch.tag", cmdWatch.PersistentFlags().Lookup("tag"))
+	_ = viper.BindPFlag("watch.check", cmdWatch.PersistentFlags().Lookup("check"))
+	_ = viper.BindPFlag("watch.status", cmdWatch.PersistentFlags().Lookup("status"))
+	_ = viper.BindPFlag("watch.format", cmdWatch.PersistentFlags().Lookup("format"))
+
+	rootCmd.AddCommand(cmdWatch)
+}
+
+func watchCommand(_ *cobra.Command, args []string) {
+	cfg := config.NewViperConfigFromViper(viper.GetViper(), "rsca")
+	logLevel := slog.LevelInfo
+	if cfg.GetBool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	_, logger := helpers.LogManager(logLevel)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	statuses, err := parseStatuses(cfg.GetStringSlice("watch.status"))
+	if err != nil {
+		logger.ErrorContext(ctx, "invalid status filter", slogtool.ErrorAttr(err))
+		return
+	}
+
+	tmpl, err := parseFormat(cfg.GetString("watch.format"))
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to load template engine", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	gc := dialGRPC(ctx, cfg, logger)
+
+	cc := api.NewAdminClient(gc)
+
+	stream, err := cc.Watch(ctx, api.WatchRequest_builder{
+		Hostnames: args,
+		Tags:      cfg.GetStringSlice("watch.tag"),
+		Checks:    cfg.GetStringSlice("watch.check"),
+		Statuses:  statuses,
+	}.Build())
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to receive Watch stream from server", slogtool.ErrorAttr(err))
+		panic(err)
+	}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return
+		}
+
+		if err != nil {
+			logger.ErrorContext(ctx, "unable to receive events from server", slogtool.ErrorAttr(err))
+			return
+		}
+
+		if in.GetDropped() > 0 {
+			logger.WarnContext(ctx, "events dropped by server, not keeping up",
+				slog.Uint64("watch.dropped", in.GetDropped()),
+			)
+		}
+
+		if err := tmpl.Execute(os.Stdout, model.EventFromAPI(in)); err != nil {
+			logger.ErrorContext(ctx, "error displaying event", slogtool.ErrorAttr(err))
+		}
+	}
+}
@@ -19,11 +19,12 @@ import (
 	"github.com/na4ma4/rsca/internal/checks"
 	"github.com/na4ma4/rsca/internal/helpers"
 	"github.com/na4ma4/rsca/internal/mainconfig"
+	"github.com/na4ma4/rsca/internal/nrpe"
 	"github.com/na4ma4/rsca/internal/register"
+	"github.com/na4ma4/rsca/internal/spool"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/grpc"
 )
 
 var rootCmd = &cobra.Command{
@@ -52,21 +53,13 @@ func main() {
 }
 
 func mainCommand(_ *cobra.Command, _ []string) {
-	cfg := config.NewViperConfDFromViper(viper.GetViper(), "/etc/nagios/rsca.d/", "rsca")
+	cfg := config.NewViperConfDFromViper(viper.GetViper(), viper.GetString("config.path"), "rsca")
 	_, logger := helpers.LogManager(slog.LevelInfo)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	eg, ctx := errgroup.WithContext(ctx)
-	serverHostName, _, _ := net.SplitHostPort(grpcServer(cfg.GetString("client.server")))
-
-	if cfg.GetString("client.sni") != "" {
-		serverHostName = cfg.GetString("client.sni")
-	}
-
-	logger.DebugContext(ctx, "Connecting to API", slog.String("bind", grpcServer(cfg.GetString("client.server"))),
-		slog.String("dns-name", serverHostName))
 
 	cp, cpErr := certprovider.NewFileProvider(
 		cfg.GetString("client.cert-dir"),
@@ -74,34 +67,70 @@ func mainCommand(_ *cobra.Command, _ []string) {
 	)
 	checkErrFatal(cpErr, logger, "failed to get certificates")
 
-	gc, gcErr := grpc.NewClient(grpcServer(cfg.GetString("client.server")), cp.DialOption(serverHostName))
-	checkErrFatal(gcErr, logger, "failed to connect to server")
+	targets, dialErr := dialTargets(ctx, logger, cp, getServers(cfg))
+	if dialErr != nil {
+		logger.ErrorContext(ctx, "no usable servers configured", slogtool.ErrorAttr(dialErr))
+		os.Exit(1)
+	}
 
-	rc := api.NewRSCAClient(gc)
 	respChan := make(chan *api.EventMessage)
 
-	stream, streamErr := rc.Pipe(ctx)
-	checkErrFatal(streamErr, logger, "unable to create stream")
-
 	hostName := getHostname(cfg)
 	checkList := checks.GetChecksFromViper(cfg, viper.GetViper(), logger, hostName)
-	cl := client.NewClient(logger, hostName, checkList)
 	regmsg := register.New(cfg, hostName, cliversion.Get(), checkList, time.Now())
-	streamMsg := api.Message_builder{
-		Envelope:        api.Envelope_builder{Sender: regmsg.Member(), Recipient: api.MembersByID("_server")}.Build(),
-		RegisterMessage: regmsg.Message(),
-	}.Build()
+	cl := client.NewClient(logger, hostName, checkList, regmsg)
+
+	if cfg.GetBool("client.spool.enabled") {
+		sp, spErr := spool.New(
+			logger,
+			cfg.GetString("client.spool.path"),
+			int64(cfg.GetInt("client.spool.max-size")),
+			cfg.GetDuration("client.spool.max-age"),
+		)
+		checkErrFatal(spErr, logger, "failed to open spool")
+
+		if sp != nil {
+			cl.SetSpool(sp)
+		}
+	}
+
 	c := make(chan os.Signal, 1)
+	hup := make(chan os.Signal, 1)
 
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	eg.Go(cl.Pipe(ctx, cancel, cfg, stream))
-	eg.Go(checks.RunChecks(ctx, cfg, logger, checkList, respChan))
+	signal.Notify(hup, syscall.SIGHUP)
+
+	scheduler := checks.NewScheduler(cfg, logger, checkList, respChan)
+	rl := &reloader{
+		logger:     logger,
+		hostName:   hostName,
+		configFile: viper.ConfigFileUsed(),
+		confdPath:  viper.GetString("config.path"),
+		scheduler:  scheduler,
+		client:     cl,
+		register:   regmsg,
+	}
+	eg.Go(cl.RunRetries(ctx, cfg))
+	eg.Go(cl.Pipe(ctx, cfg, targets))
+	eg.Go(scheduler.Run(ctx))
+	eg.Go(rl.Run(ctx, hup))
 	eg.Go(helpers.WaitForOSSignal(ctx, cancel, cfg, logger, c))
 	eg.Go(helpers.ProcessWatchdog(ctx, cancel, cfg, logger))
-	eg.Go(cl.RunEvents(ctx, cancel, regmsg, respChan))
+	eg.Go(cl.RunEvents(ctx, respChan))
+
+	if cfg.GetBool("client.submit.enabled") {
+		sub := client.NewSubmitServer(logger, hostName, cfg.GetStringSlice("client.submit.allow-hosts"), respChan)
+		eg.Go(sub.Run(ctx, cfg.GetString("client.submit.socket")))
+	}
+
+	if cfg.GetBool("nrpe.enabled") {
+		ns, nsErr := nrpe.NewServer(cfg, logger, cl.GetCheck, cliversion.GetBuildVersion(cliversion.Get()))
+		if nsErr != nil {
+			logger.ErrorContext(ctx, "failed to configure nrpe server", slogtool.ErrorAttr(nsErr))
+			os.Exit(1)
+		}
 
-	if err := stream.Send(streamMsg); err != nil {
-		logger.ErrorContext(ctx, "unable to register with server", slogtool.ErrorAttr(err))
+		eg.Go(ns.Run(ctx))
 	}
 
 	if err := eg.Wait(); err != nil {
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/client"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/na4ma4/rsca/internal/mainconfig"
+	"github.com/na4ma4/rsca/internal/register"
+	"github.com/spf13/viper"
+)
+
+// reloader re-reads the configuration and applies the changes to the running client.
+type reloader struct {
+	logger   *slog.Logger
+	hostName string
+
+	// configFile is the config file read at startup, confdPath is the directory of additional
+	// config files.
+	configFile string
+	confdPath  string
+
+	scheduler *checks.Scheduler
+	client    *client.Client
+	register  *register.Message
+}
+
+// Run is a routine that reloads the configuration each time a signal is received on c.
+func (r *reloader) Run(ctx context.Context, c chan os.Signal) func() error {
+	return func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-c:
+				r.Reload(ctx)
+			}
+		}
+	}
+}
+
+// Reload re-reads the configuration, replaces the check list and tags and sends the updated
+// member details to the server.
+//
+// The configuration is read into a new viper.Viper, the global config is still in use by the
+// running routines.
+func (r *reloader) Reload(ctx context.Context) {
+	r.logger.InfoContext(ctx, "reloading configuration")
+
+	vcfg := viper.New()
+	mainconfig.Defaults(vcfg)
+
+	if r.configFile != "" {
+		vcfg.SetConfigFile(r.configFile)
+	}
+
+	if err := vcfg.ReadInConfig(); err != nil {
+		r.logger.ErrorContext(ctx, "unable to read configuration, keeping current configuration",
+			slogtool.ErrorAttr(err),
+		)
+
+		return
+	}
+
+	cfg := config.NewViperConfDFromViper(vcfg, r.confdPath, "rsca")
+	checkList := r.scheduler.Reload(ctx, checks.GetChecksFromViper(cfg, vcfg, r.logger, r.hostName))
+
+	if checkList == nil {
+		return
+	}
+
+	r.client.SetChecks(checkList)
+	r.register.SetServices(checkList)
+	r.register.SetTags(cfg.GetStringSlice("general.tags"))
+	r.client.SendRepeatRegistration(ctx)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-certprovider"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/client"
+	"google.golang.org/grpc"
+)
+
+// serverConfig is an entry in the `client.servers` list.
+type serverConfig struct {
+	Address string
+	SNI     string
+}
+
+// getServers returns the ordered list of servers from `client.servers`, falling back to the
+// single `client.server` and `client.sni` settings.
+//
+// Entries can either be a plain address or a table with `address` and optional `sni` keys.
+func getServers(cfg config.Conf) []serverConfig {
+	out := []serverConfig{}
+
+	var list []interface{}
+
+	switch v := cfg.Get("client.servers").(type) {
+	case []interface{}:
+		list = v
+	case []map[string]interface{}:
+		for _, item := range v {
+			list = append(list, item)
+		}
+	case []string:
+		for _, item := range v {
+			list = append(list, item)
+		}
+	}
+
+	for _, v := range list {
+		switch item := v.(type) {
+		case string:
+			out = append(out, serverConfig{Address: item})
+		case map[string]interface{}:
+			address, _ := item["address"].(string)
+			sni, _ := item["sni"].(string)
+
+			if address != "" {
+				out = append(out, serverConfig{Address: address, SNI: sni})
+			}
+		}
+	}
+
+	if len(out) == 0 {
+		out = append(out, serverConfig{
+			Address: cfg.GetString("client.server"),
+			SNI:     cfg.GetString("client.sni"),
+		})
+	}
+
+	return out
+}
+
+// dialTargets creates a gRPC client for each of the configured servers, servers that can not be
+// configured are skipped and an error is returned if none of the servers are usable.
+func dialTargets(
+	ctx context.Context,
+	logger *slog.Logger,
+	cp certprovider.CertificateProvider,
+	servers []serverConfig,
+) ([]*client.Target, error) {
+	targets := make([]*client.Target, 0, len(servers))
+
+	for _, srv := range servers {
+		serverHostName, _, _ := net.SplitHostPort(grpcServer(srv.Address))
+
+		if srv.SNI != "" {
+			serverHostName = srv.SNI
+		}
+
+		logger.DebugContext(ctx, "Connecting to API", slog.String("bind", grpcServer(srv.Address)),
+			slog.String("dns-name", serverHostName))
+
+		gc, gcErr := grpc.NewClient(grpcServer(srv.Address), cp.DialOption(serverHostName))
+		if gcErr != nil {
+			logger.ErrorContext(ctx, "failed to connect to server", slog.String("bind", grpcServer(srv.Address)),
+				slogtool.ErrorAttr(gcErr))
+
+			continue
+		}
+
+		targets = append(targets, &client.Target{
+			Address: grpcServer(srv.Address),
+			SNI:     serverHostName,
+			Client:  api.NewRSCAClient(gc),
+		})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("dial servers: %w", client.ErrNoTargets)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errInvalidCheckType = errors.New("invalid check type")
+	errInvalidStatus    = errors.New("invalid status")
+)
+
+var cmdSubmit = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit a passive check result through the running agent",
+	Run:   submitCommand,
+	Args:  cobra.NoArgs,
+}
+
+func init() {
+	cmdSubmit.PersistentFlags().StringP("check", "k", "", "Check name (required for service results)")
+	cmdSubmit.PersistentFlags().IntP("status", "s", 0, "Check status (0 = OK, 1 = WARNING, 2 = CRITICAL, 3 = UNKNOWN)")
+	cmdSubmit.PersistentFlags().StringP("output", "o", "", "Check output, use '-' to read from stdin")
+	cmdSubmit.PersistentFlags().String("host", "", "Hostname the result is for (defaults to the local hostname)")
+	cmdSubmit.PersistentFlags().String("type", "service", "Check type (host or service)")
+	cmdSubmit.PersistentFlags().String("socket", "", "Path to the agent submit socket")
+
+	_ = viper.BindPFlag("submit.check", cmdSubmit.PersistentFlags().Lookup("check"))
+	_ = viper.BindPFlag("submit.status", cmdSubmit.PersistentFlags().Lookup("status"))
+	_ = viper.BindPFlag("submit.output", cmdSubmit.PersistentFlags().Lookup("output"))
+	_ = viper.BindPFlag("submit.host", cmdSubmit.PersistentFlags().Lookup("host"))
+	_ = viper.BindPFlag("submit.type", cmdSubmit.PersistentFlags().Lookup("type"))
+	_ = viper.BindPFlag("client.submit.socket", cmdSubmit.PersistentFlags().Lookup("socket"))
+
+	rootCmd.AddCommand(cmdSubmit)
+}
+
+//nolint:forbidigo // Display Function
+func submitCommand(_ *cobra.Command, _ []string) {
+	cfg := config.NewViperConfDFromViper(viper.GetViper(), viper.GetString("config.path"), "rsca")
+	_, logger := helpers.LogManager(slog.LevelInfo)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetDuration("client.submit.timeout"))
+	defer cancel()
+
+	msg, err := submitMessage(cfg)
+	if err != nil {
+		logger.ErrorContext(ctx, "invalid check result", slogtool.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	gc, err := grpc.NewClient(
+		"unix://"+cfg.GetString("client.submit.socket"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to connect to agent", slogtool.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	defer gc.Close()
+
+	r, err := api.NewLocalClient(gc).Submit(ctx, msg)
+	if err != nil {
+		logger.ErrorContext(ctx, "unable to submit check result", slogtool.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	fmt.Printf("Check result submitted: %s\n", r.GetId())
+}
+
+// submitMessage builds the api.EventMessage from the submit flags.
+func submitMessage(cfg config.Conf) (*api.EventMessage, error) {
+	output := cfg.GetString("submit.output")
+
+	if output == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read output from stdin: %w", err)
+		}
+
+		output = string(b)
+	}
+
+	checkType := api.CheckType_SERVICE
+
+	switch strings.ToLower(cfg.GetString("submit.type")) {
+	case "host":
+		checkType = api.CheckType_HOST
+	case "", "service":
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidCheckType, cfg.GetString("submit.type"))
+	}
+
+	status := cfg.GetInt("submit.status")
+	if _, ok := api.Status_name[int32(status)]; !ok { //nolint:gosec // validated by lookup.
+		return nil, fmt.Errorf("%w: %d", errInvalidStatus, status)
+	}
+
+	hostName := cfg.GetString("submit.host")
+	if hostName == "" {
+		hostName = getHostname(cfg)
+	}
+
+	text, longOutput, perfdata := checks.ParseOutput(output)
+
+	return api.EventMessage_builder{
+		Hostname:   proto.String(hostName),
+		Type:       &checkType,
+		Check:      proto.String(cfg.GetString("submit.check")),
+		Status:     api.Status(status).Enum(), //nolint:gosec // validated above.
+		Output:     proto.String(text),
+		LongOutput: proto.String(longOutput),
+		Perfdata:   proto.String(perfdata),
+	}.Build(), nil
+}
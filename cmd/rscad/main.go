@@ -13,8 +13,13 @@ import (
 	"github.com/na4ma4/go-certprovider"
 	"github.com/na4ma4/go-slogtool"
 	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/audit"
+	"github.com/na4ma4/rsca/internal/authz"
 	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/identity"
 	"github.com/na4ma4/rsca/internal/mainconfig"
+	"github.com/na4ma4/rsca/internal/nsca"
+	"github.com/na4ma4/rsca/internal/sink"
 	"github.com/na4ma4/rsca/internal/state"
 	"github.com/na4ma4/rsca/server"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -78,22 +83,109 @@ func mainCommand(_ *cobra.Command, _ []string) {
 
 	defer st.Close()
 
+	sinks, sinkErr := sink.GetChainFromViper(cfg, viper.GetViper(), logger)
+	if sinkErr != nil {
+		logger.ErrorContext(ctx, "failed to configure result sinks", slogtool.ErrorAttr(sinkErr))
+		panic(sinkErr)
+	}
+
 	// hostName := getHostname(cfg)
 	eg, ctx := errgroup.WithContext(ctx)
-	sapi := server.NewServer(logger, st)
-	gc := grpc.NewServer(cp.ServerOption())
+	sapi := server.NewServer(logger, st, sinks)
+	serverOpts := []grpc.ServerOption{cp.ServerOption()}
+
+	if cfg.GetBool("audit.enabled") {
+		auditLog, auditErr := audit.NewLog(
+			cfg.GetString("audit.path"),
+			int64(cfg.GetInt("audit.max-size-mb"))*1024*1024, //nolint:mnd // megabytes.
+			cfg.GetInt("audit.max-backups"),
+		)
+		if auditErr != nil {
+			logger.ErrorContext(ctx, "failed to open audit log", slogtool.ErrorAttr(auditErr))
+			panic(auditErr)
+		}
+
+		sapi.SetAuditLog(auditLog)
+		serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(auditLog.UnaryInterceptor(logger)))
+	}
+
+	if cfg.GetBool("authz.enabled") {
+		az, azErr := authz.NewAuthorizer(cfg, logger)
+		if azErr != nil {
+			logger.ErrorContext(ctx, "failed to configure admin authorization", slogtool.ErrorAttr(azErr))
+			panic(azErr)
+		}
+
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(az.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(az.StreamInterceptor()),
+		)
+	}
+
+	gc := grpc.NewServer(serverOpts...)
+
+	if cfg.GetBool("identity.enabled") {
+		policy, policyErr := identity.NewPolicy(cfg)
+		if policyErr != nil {
+			logger.ErrorContext(ctx, "failed to configure identity policy", slogtool.ErrorAttr(policyErr))
+			panic(policyErr)
+		}
+
+		sapi.SetIdentityPolicy(policy)
+	}
 
 	api.RegisterRSCAServer(gc, sapi)
-	api.RegisterAdminServer(gc, sapi)
+
+	if adminListen := cfg.GetString("server.admin-listen"); adminListen != "" {
+		adminLis, adminErr := helpers.Listen(ctx, adminListen)
+		if adminErr != nil {
+			logger.ErrorContext(ctx, "failed to listen for admin requests", slogtool.ErrorAttr(adminErr))
+			panic(adminErr)
+		}
+
+		ac := grpc.NewServer(serverOpts...)
+		api.RegisterAdminServer(ac, sapi)
+
+		logger.InfoContext(ctx, "admin server listening", slog.String("bind", adminListen))
+
+		eg.Go(func() error { return ac.Serve(adminLis) })
+		eg.Go(stopOnDone(ctx, ac))
+	} else {
+		api.RegisterAdminServer(gc, sapi)
+	}
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
 	eg.Go(helpers.WaitForOSSignal(ctx, cancel, cfg, logger, c))
 	eg.Go(sapi.Run(ctx, cfg))
-	eg.Go(helpers.StateReaper(ctx, cfg, logger, st))
+	eg.Go(sinks.Run(ctx))
+	eg.Go(helpers.StateReaper(ctx, cfg, logger, st, sapi.HostReaped))
 	eg.Go(helpers.ProcessWatchdog(ctx, cancel, cfg, logger))
 	eg.Go(func() error { return gc.Serve(lis) })
+	eg.Go(stopOnDone(ctx, gc))
+
+	if cfg.GetBool("host-status.enabled") {
+		eg.Go(sapi.RunHostStatus(ctx, cfg))
+	}
+
+	if cfg.GetBool("freshness.enabled") {
+		eg.Go(sapi.RunFreshness(ctx, cfg))
+	}
+
+	if cfg.GetBool("history.enabled") {
+		eg.Go(sapi.RunHistory(ctx, cfg))
+	}
+
+	if cfg.GetBool("nsca.enabled") {
+		ns, nsErr := nsca.NewServer(cfg, logger, sapi.HandleEvent)
+		if nsErr != nil {
+			logger.ErrorContext(ctx, "failed to configure nsca server", slogtool.ErrorAttr(nsErr))
+			panic(nsErr)
+		}
+
+		eg.Go(ns.Run(ctx))
+	}
 
 	if cfg.GetBool("metrics.enabled") {
 		go func() {
@@ -115,7 +207,21 @@ func mainCommand(_ *cobra.Command, _ []string) {
 		}()
 	}
 
-	<-ctx.Done()
+	// wait for the routines to return so the queued check results are written out by the sinks.
+	if err := eg.Wait(); err != nil {
+		logger.DebugContext(ctx, "routines returned", slogtool.ErrorAttr(err))
+	}
+}
+
+// stopOnDone returns a routine that stops the gRPC server when the context is cancelled, the client
+// streams do not end on their own so the server is not stopped gracefully.
+func stopOnDone(ctx context.Context, gs *grpc.Server) func() error {
+	return func() error {
+		<-ctx.Done()
+		gs.Stop()
+
+		return nil
+	}
 }
 
 // func getHostname(cfg config.Conf) string {
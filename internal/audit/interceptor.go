@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/authz"
+	"github.com/na4ma4/rsca/internal/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Audited returns true if the method is recorded in the audit log, these are the Admin methods that
+// require the write role.
+func Audited(fullMethod string) bool {
+	role, ok := authz.MethodRole(fullMethod)
+
+	return ok && role == authz.RoleWrite
+}
+
+// UnaryInterceptor returns a gRPC interceptor that records audited requests in the audit log, it
+// should be the first interceptor so requests denied by authorization are recorded.
+func (l *Log) UnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !Audited(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		entry := NewEntry(ctx, info.FullMethod, req, resp, err)
+		entry.SetTimestamp(timestamppb.New(start))
+		entry.SetDuration(durationpb.New(time.Since(start)))
+
+		if writeErr := l.Write(entry); writeErr != nil {
+			logger.ErrorContext(ctx, "unable to write audit log",
+				slog.String("grpc.method", info.FullMethod), slogtool.ErrorAttr(writeErr),
+			)
+		}
+
+		return resp, err
+	}
+}
+
+// NewEntry returns the audit entry of a request from the client of the context.
+func NewEntry(ctx context.Context, fullMethod string, req, resp any, err error) *api.AuditEntry {
+	st := status.Convert(err)
+
+	entry := api.AuditEntry_builder{
+		Method:        &fullMethod,
+		Targets:       Targets(req),
+		AffectedHosts: AffectedHosts(resp),
+	}.Build()
+
+	entry.SetOutcome(st.Code().String())
+
+	if err != nil {
+		entry.SetError(st.Message())
+	}
+
+	if cert, ok := identity.CertificateFromContext(ctx); ok {
+		entry.SetIdentity(identity.FromCertificate(cert).Names())
+		entry.SetFingerprint(identity.Fingerprint(cert))
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		entry.SetPeerAddress(p.Addr.String())
+	}
+
+	return entry
+}
+
+// Targets returns the hosts and checks requested by an Admin request, hosts selected by a
+// property other than the name are prefixed with the property (eg. `tag=web`).
+func Targets(req any) []string {
+	switch v := req.(type) {
+	case *api.RemoveHostRequest:
+		return v.GetNames()
+	case *api.Members:
+		return memberTargets(v)
+	case *api.TriggerCheckRequest:
+		out := memberTargets(v.GetMembers())
+		for _, check := range v.GetChecks() {
+			out = append(out, "check="+check)
+		}
+
+		return out
+	case *api.RunCheckRequest:
+		return []string{v.GetHostname(), "check=" + v.GetCheck()}
+	default:
+		return nil
+	}
+}
+
+// memberTargets returns the targets of a member selector.
+func memberTargets(m *api.Members) []string {
+	out := slices.Clone(m.GetName())
+
+	for _, prop := range []struct {
+		name   string
+		values []string
+	}{
+		{"id", m.GetId()},
+		{"capability", m.GetCapability()},
+		{"tag", m.GetTag()},
+		{"service", m.GetService()},
+	} {
+		for _, v := range prop.values {
+			out = append(out, prop.name+"="+v)
+		}
+	}
+
+	return out
+}
+
+// AffectedHosts returns the hosts an Admin request was applied to from the response.
+func AffectedHosts(resp any) []string {
+	switch v := resp.(type) {
+	case *api.RemoveHostResponse:
+		return v.GetNames()
+	case *api.TriggerAllResponse:
+		return v.GetNames()
+	case *api.TriggerInfoResponse:
+		return v.GetNames()
+	case *api.TriggerCheckResponse:
+		out := []string{}
+
+		for _, c := range v.GetChecks() {
+			if !slices.Contains(out, c.GetHostname()) {
+				out = append(out, c.GetHostname())
+			}
+		}
+
+		return out
+	case *api.RunCheckResponse:
+		if v.HasEvent() {
+			return []string{v.GetEvent().GetHostname()}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
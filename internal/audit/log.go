@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxLineSize is the largest audit entry that is read back.
+const maxLineSize = 1024 * 1024
+
+// Log is an append-only audit log with one JSON entry per line, the file is rotated to `<path>.1`
+// (and older files to `<path>.2` and so on) when it reaches the maximum size.
+type Log struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	lock       sync.Mutex
+}
+
+// NewLog returns a Log that appends to the file at path, it is rotated when it would grow larger
+// than maxSize bytes (0 disables rotation) and maxBackups rotated files are kept.
+func NewLog(path string, maxSize int64, maxBackups int) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permbits.MustString("u=rw,g=r"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log: %w", err)
+	}
+
+	_ = f.Close()
+
+	return &Log{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}, nil
+}
+
+// Write appends the entry to the audit log and syncs it to disk.
+func (l *Log) Write(entry *api.AuditEntry) error {
+	data, err := protojson.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit entry: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err = l.rotate(int64(len(data))); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permbits.MustString("u=rw,g=r"))
+	if err != nil {
+		return fmt.Errorf("unable to open audit log: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	if _, err = f.Write(data); err != nil {
+		return fmt.Errorf("unable to write audit log: %w", err)
+	}
+
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("unable to sync audit log: %w", err)
+	}
+
+	return nil
+}
+
+// rotate renames the audit log to the first backup if writing size bytes would make it larger than
+// the maximum size, the oldest backup is removed.
+func (l *Log) rotate(size int64) error {
+	if l.maxSize <= 0 {
+		return nil
+	}
+
+	st, err := os.Stat(l.path)
+	if err != nil || st.Size() == 0 || st.Size()+size <= l.maxSize {
+		return nil //nolint:nilerr // a missing file is created by the write.
+	}
+
+	if l.maxBackups <= 0 {
+		if err = os.Remove(l.path); err != nil {
+			return fmt.Errorf("unable to remove audit log: %w", err)
+		}
+
+		return nil
+	}
+
+	for i := l.maxBackups - 1; i > 0; i-- {
+		if err = os.Rename(l.backup(i), l.backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to rotate audit log: %w", err)
+		}
+	}
+
+	if err = os.Rename(l.path, l.backup(1)); err != nil {
+		return fmt.Errorf("unable to rotate audit log: %w", err)
+	}
+
+	return nil
+}
+
+// backup returns the filename of the numbered backup.
+func (l *Log) backup(n int) string {
+	return l.path + "." + strconv.Itoa(n)
+}
+
+// Read returns the entries written at or after since (a zero time is unbounded) from the audit log
+// and its backups, oldest first. At most limit of the most recent entries are returned (0 for all).
+func (l *Log) Read(since time.Time, limit int) ([]*api.AuditEntry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	out := []*api.AuditEntry{}
+
+	files := []string{}
+	for i := l.maxBackups; i > 0; i-- {
+		files = append(files, l.backup(i))
+	}
+
+	for _, filename := range append(files, l.path) {
+		entries, err := readFile(filename, since)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, entries...)
+	}
+
+	if limit > 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+
+	return out, nil
+}
+
+// readFile returns the entries written at or after since from an audit log file, a missing file
+// has no entries.
+func readFile(filename string, since time.Time) ([]*api.AuditEntry, error) {
+	f, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	out := []*api.AuditEntry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for scanner.Scan() {
+		// skip lines that can not be parsed, eg. a partial write before a crash.
+		entry := &api.AuditEntry{}
+		if protojson.Unmarshal(scanner.Bytes(), entry) != nil {
+			continue
+		}
+
+		if !since.IsZero() && entry.GetTimestamp().AsTime().Before(since) {
+			continue
+		}
+
+		out = append(out, entry)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read audit log %s: %w", filename, err)
+	}
+
+	return out, nil
+}
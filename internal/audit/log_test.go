@@ -0,0 +1,143 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testEntry(n int, ts time.Time) *api.AuditEntry {
+	return api.AuditEntry_builder{
+		Timestamp: timestamppb.New(ts),
+		Method:    proto.String(fmt.Sprintf("method-%d", n)),
+		Outcome:   proto.String("OK"),
+	}.Build()
+}
+
+func methods(entries []*api.AuditEntry) []string {
+	out := []string{}
+	for _, e := range entries {
+		out = append(out, e.GetMethod())
+	}
+
+	return out
+}
+
+func TestLogRotateAndRead(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	l, err := audit.NewLog(path, 200, 2)
+	if err != nil {
+		t.Fatalf("audit.NewLog(): error, got '%s', want 'nil'", err)
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 8 {
+		if err = l.Write(testEntry(i, base.Add(time.Duration(i)*time.Minute))); err != nil {
+			t.Fatalf("Log.Write(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		if _, err = os.Stat(name); err != nil {
+			t.Errorf("os.Stat(%s): error, got '%s', want 'nil'", name, err)
+		}
+	}
+
+	if _, err = os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(%s.3): error, got '%v', want not exist", path, err)
+	}
+
+	entries, err := l.Read(time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("Log.Read(): error, got '%s', want 'nil'", err)
+	}
+
+	// each file holds two entries, the oldest entries were rotated out.
+	want := []string{"method-2", "method-3", "method-4", "method-5", "method-6", "method-7"}
+	if diff := cmp.Diff(want, methods(entries)); diff != "" {
+		t.Errorf("Log.Read(): -want +got:\n%s", diff)
+	}
+
+	if entries, err = l.Read(base.Add(5*time.Minute), 1); err != nil {
+		t.Fatalf("Log.Read(): error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff([]string{"method-7"}, methods(entries)); diff != "" {
+		t.Errorf("Log.Read(): since and limit -want +got:\n%s", diff)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("os.OpenFile(): error, got '%s', want 'nil'", err)
+	}
+
+	_, _ = f.WriteString(`{"method":"trunc`)
+	_ = f.Close()
+
+	if entries, err = l.Read(base.Add(6*time.Minute), 0); err != nil {
+		t.Fatalf("Log.Read(): partial line error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff([]string{"method-6", "method-7"}, methods(entries)); diff != "" {
+		t.Errorf("Log.Read(): partial line -want +got:\n%s", diff)
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+
+	entry := audit.NewEntry(context.Background(), api.Admin_TriggerCheck_FullMethodName,
+		api.TriggerCheckRequest_builder{
+			Members: api.Members_builder{Name: []string{"web01"}, Tag: []string{"db"}}.Build(),
+			Checks:  []string{"DISK"},
+		}.Build(),
+		api.TriggerCheckResponse_builder{Checks: []*api.TriggeredCheck{
+			api.TriggeredCheck_builder{Hostname: proto.String("web01"), Check: proto.String("DISK")}.Build(),
+			api.TriggeredCheck_builder{Hostname: proto.String("db01"), Check: proto.String("DISK")}.Build(),
+			api.TriggeredCheck_builder{Hostname: proto.String("web01"), Check: proto.String("disk")}.Build(),
+		}}.Build(),
+		nil,
+	)
+
+	if diff := cmp.Diff([]string{"web01", "tag=db", "check=DISK"}, entry.GetTargets()); diff != "" {
+		t.Errorf("audit.NewEntry(): targets -want +got:\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"web01", "db01"}, entry.GetAffectedHosts()); diff != "" {
+		t.Errorf("audit.NewEntry(): affected hosts -want +got:\n%s", diff)
+	}
+
+	if entry.GetOutcome() != "OK" || entry.GetError() != "" {
+		t.Errorf("audit.NewEntry(): outcome got '%s' '%s', want 'OK' ''", entry.GetOutcome(), entry.GetError())
+	}
+
+	entry = audit.NewEntry(context.Background(), api.Admin_RemoveHost_FullMethodName,
+		api.RemoveHostRequest_builder{Names: []string{"web01"}}.Build(), nil,
+		status.Error(codes.PermissionDenied, "denied"),
+	)
+
+	if entry.GetOutcome() != "PermissionDenied" || entry.GetError() != "denied" {
+		t.Errorf("audit.NewEntry(): outcome got '%s' '%s', want 'PermissionDenied' 'denied'",
+			entry.GetOutcome(), entry.GetError(),
+		)
+	}
+
+	if !audit.Audited(api.Admin_RemoveHost_FullMethodName) || audit.Audited(api.Admin_ListHosts_FullMethodName) {
+		t.Errorf("audit.Audited(): only mutating Admin methods should be audited")
+	}
+}
@@ -0,0 +1,255 @@
+package authz
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/identity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrInvalidFingerprint is returned when a configured fingerprint is not a hex SHA-256 fingerprint.
+var ErrInvalidFingerprint = errors.New("invalid certificate fingerprint")
+
+// adminServicePrefix is the prefix of the full method names of the Admin service.
+const adminServicePrefix = "/rsca.api.Admin/"
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var metricRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name:      "requests_total",
+	Namespace: "rsca",
+	Subsystem: "authz",
+	Help:      "number of Admin requests by method and result",
+}, []string{"method", "result"})
+
+// Role is the access required by an Admin method.
+type Role int
+
+const (
+	// RoleNone is not allowed to call any Admin methods.
+	RoleNone Role = iota
+	// RoleRead can call the Admin methods that do not change state or run checks.
+	RoleRead
+	// RoleWrite can call all Admin methods.
+	RoleWrite
+)
+
+// String returns the name of the role.
+func (r Role) String() string {
+	switch r {
+	case RoleNone:
+		return "none"
+	case RoleRead:
+		return "read"
+	case RoleWrite:
+		return "write"
+	default:
+		return "unknown"
+	}
+}
+
+// MethodRole returns the role required to call the method, false is returned for methods that are
+// not part of the Admin service. Admin methods that are not known require RoleWrite.
+func MethodRole(fullMethod string) (Role, bool) {
+	switch fullMethod {
+	case api.Admin_ListHosts_FullMethodName,
+		api.Admin_ListResults_FullMethodName,
+		api.Admin_GetResultHistory_FullMethodName,
+		api.Admin_Watch_FullMethodName,
+		api.Admin_ListAudit_FullMethodName:
+		return RoleRead, true
+	}
+
+	if strings.HasPrefix(fullMethod, adminServicePrefix) {
+		return RoleWrite, true
+	}
+
+	return RoleNone, false
+}
+
+// Rule matches client certificates by organisational unit, name or fingerprint, an empty rule does
+// not match any certificate.
+type Rule struct {
+	// OUs match certificates with any of the organisational units.
+	OUs []string
+
+	// Names are patterns (eg. `ops-*`) matched against the common name and DNS names.
+	Names []string
+
+	// Fingerprints are lowercase hex SHA-256 certificate fingerprints.
+	Fingerprints []string
+}
+
+// NewRule returns a Rule, fingerprints can be separated with colons.
+func NewRule(ous, names, fingerprints []string) (Rule, error) {
+	r := Rule{OUs: ous}
+
+	for _, name := range names {
+		if _, err := path.Match(strings.ToLower(name), ""); err != nil {
+			return r, fmt.Errorf("invalid name pattern %s: %w", name, err)
+		}
+
+		r.Names = append(r.Names, strings.ToLower(name))
+	}
+
+	for _, v := range fingerprints {
+		fp := strings.ToLower(strings.ReplaceAll(v, ":", ""))
+
+		if b, err := hex.DecodeString(fp); err != nil || len(b) != 32 { //nolint:mnd // SHA-256 size.
+			return r, fmt.Errorf("%w: %s", ErrInvalidFingerprint, v)
+		}
+
+		r.Fingerprints = append(r.Fingerprints, fp)
+	}
+
+	return r, nil
+}
+
+// Match returns true if the certificate matches any of the rule criteria.
+func (r Rule) Match(cert *x509.Certificate) bool {
+	if slices.ContainsFunc(cert.Subject.OrganizationalUnit, func(ou string) bool {
+		return slices.ContainsFunc(r.OUs, func(v string) bool { return strings.EqualFold(v, ou) })
+	}) {
+		return true
+	}
+
+	if slices.ContainsFunc(identity.FromCertificate(cert).Names(), func(name string) bool {
+		return slices.ContainsFunc(r.Names, func(pattern string) bool {
+			ok, _ := path.Match(pattern, strings.ToLower(name))
+
+			return ok
+		})
+	}) {
+		return true
+	}
+
+	return slices.Contains(r.Fingerprints, identity.Fingerprint(cert))
+}
+
+// Authorizer checks the client certificate of Admin requests against the read and write rules.
+type Authorizer struct {
+	logger *slog.Logger
+	read   Rule
+	write  Rule
+}
+
+// NewAuthorizer returns an Authorizer with the rules configured in `authz.read` and `authz.write`.
+func NewAuthorizer(cfg config.Conf, logger *slog.Logger) (*Authorizer, error) {
+	read, err := NewRule(
+		cfg.GetStringSlice("authz.read.ou"),
+		cfg.GetStringSlice("authz.read.names"),
+		cfg.GetStringSlice("authz.read.fingerprints"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse authz.read: %w", err)
+	}
+
+	write, err := NewRule(
+		cfg.GetStringSlice("authz.write.ou"),
+		cfg.GetStringSlice("authz.write.names"),
+		cfg.GetStringSlice("authz.write.fingerprints"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse authz.write: %w", err)
+	}
+
+	return &Authorizer{
+		logger: logger,
+		read:   read,
+		write:  write,
+	}, nil
+}
+
+// Role returns the role granted to the certificate, the write role includes the read role.
+func (a *Authorizer) Role(cert *x509.Certificate) Role {
+	switch {
+	case a.write.Match(cert):
+		return RoleWrite
+	case a.read.Match(cert):
+		return RoleRead
+	default:
+		return RoleNone
+	}
+}
+
+// Authorize returns a gRPC status error if the client is not allowed to call the method, methods
+// that are not part of the Admin service are always allowed.
+func (a *Authorizer) Authorize(ctx context.Context, fullMethod string) error {
+	required, ok := MethodRole(fullMethod)
+	if !ok {
+		return nil
+	}
+
+	cert, ok := identity.CertificateFromContext(ctx)
+	if !ok {
+		a.deny(ctx, fullMethod, required, nil)
+
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	if a.Role(cert) < required {
+		a.deny(ctx, fullMethod, required, cert)
+
+		return status.Errorf(codes.PermissionDenied, "%s requires the %s role", path.Base(fullMethod), required)
+	}
+
+	metricRequests.WithLabelValues(path.Base(fullMethod), "allowed").Inc()
+
+	return nil
+}
+
+// deny records and audit logs a denied request.
+func (a *Authorizer) deny(ctx context.Context, fullMethod string, required Role, cert *x509.Certificate) {
+	metricRequests.WithLabelValues(path.Base(fullMethod), "denied").Inc()
+
+	attrs := []any{
+		slog.String("audit.event", "authz-denied"),
+		slog.String("grpc.method", fullMethod),
+		slog.String("authz.required", required.String()),
+	}
+
+	if cert != nil {
+		attrs = append(attrs,
+			slog.String("authz.certificate", identity.FromCertificate(cert).String()),
+			slog.String("authz.fingerprint", identity.Fingerprint(cert)),
+			slog.String("authz.role", a.Role(cert).String()),
+		)
+	}
+
+	a.logger.WarnContext(ctx, "admin request denied", attrs...)
+}
+
+// UnaryInterceptor returns a gRPC interceptor that authorizes unary Admin requests.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that authorizes streaming Admin requests.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.Authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,134 @@
+package authz_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/authz"
+	"github.com/na4ma4/rsca/internal/identity"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func testCertificate(t *testing.T, cn, ou string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey(): error, got '%s', want 'nil'", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn, OrganizationalUnit: []string{ou}},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate(): error, got '%s', want 'nil'", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate(): error, got '%s', want 'nil'", err)
+	}
+
+	return cert
+}
+
+func peerContext(cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.PeerCertificates = []*x509.Certificate{cert}
+	}
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	agent := testCertificate(t, "web01.example.com", "client")
+	operator := testCertificate(t, "ops-alice", "operators")
+	viewer := testCertificate(t, "dashboard", "client")
+
+	vcfg := viper.New()
+	vcfg.Set("authz.read.names", []string{"dash*"})
+	vcfg.Set("authz.write.ou", []string{"Operators"})
+
+	var buf bytes.Buffer
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+
+	az, err := authz.NewAuthorizer(cfg, slog.New(slog.NewJSONHandler(&buf, nil)))
+	if err != nil {
+		t.Fatalf("authz.NewAuthorizer(): error, got '%s', want 'nil'", err)
+	}
+
+	tests := []struct {
+		name   string
+		cert   *x509.Certificate
+		method string
+		want   codes.Code
+	}{
+		{"agent pipe", agent, api.RSCA_Pipe_FullMethodName, codes.OK},
+		{"agent list", agent, api.Admin_ListHosts_FullMethodName, codes.PermissionDenied},
+		{"agent remove", agent, api.Admin_RemoveHost_FullMethodName, codes.PermissionDenied},
+		{"viewer list", viewer, api.Admin_ListHosts_FullMethodName, codes.OK},
+		{"viewer watch", viewer, api.Admin_Watch_FullMethodName, codes.OK},
+		{"viewer trigger", viewer, api.Admin_TriggerAll_FullMethodName, codes.PermissionDenied},
+		{"operator list", operator, api.Admin_ListHosts_FullMethodName, codes.OK},
+		{"operator remove", operator, api.Admin_RemoveHost_FullMethodName, codes.OK},
+		{"operator unknown method", operator, "/rsca.api.Admin/Unknown", codes.OK},
+		{"viewer unknown method", viewer, "/rsca.api.Admin/Unknown", codes.PermissionDenied},
+		{"no certificate", nil, api.Admin_ListHosts_FullMethodName, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(az.Authorize(peerContext(tt.cert), tt.method)); got != tt.want {
+			t.Errorf("Authorizer.Authorize(): %s got '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"audit.event":"authz-denied"`)) {
+		t.Errorf("Authorizer.Authorize(): denied requests not audit logged: %s", buf.String())
+	}
+
+	fp, err := authz.NewRule(nil, nil, []string{identity.Fingerprint(agent)})
+	if err != nil {
+		t.Fatalf("authz.NewRule(): error, got '%s', want 'nil'", err)
+	}
+
+	if !fp.Match(agent) || fp.Match(viewer) {
+		t.Errorf("Rule.Match(): fingerprint rule matched the wrong certificate")
+	}
+}
+
+func TestNewRuleInvalidFingerprint(t *testing.T) {
+	t.Parallel()
+
+	if _, err := authz.NewRule(nil, nil, []string{"ab:cd"}); !errors.Is(err, authz.ErrInvalidFingerprint) {
+		t.Errorf("authz.NewRule(): error, got '%v', want '%v'", err, authz.ErrInvalidFingerprint)
+	}
+}
@@ -0,0 +1,358 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ErrUnknownDriver is returned when a check is configured with a driver that does not exist.
+var ErrUnknownDriver = errors.New("unknown check driver")
+
+// builtinResult is the result of a builtin check.
+type builtinResult struct {
+	Status   api.Status
+	Output   string
+	Perfdata string
+}
+
+// builtinFunc is the native implementation of a check driver.
+type builtinFunc func(ctx context.Context, i *Info) (*builtinResult, error)
+
+// builtinDrivers are the check drivers that are implemented natively instead of running a command.
+//
+//nolint:gochecknoglobals // lookup table of drivers.
+var builtinDrivers = map[string]builtinFunc{
+	"disk":   builtinDisk,
+	"load":   builtinLoad,
+	"memory": builtinMemory,
+	"swap":   builtinSwap,
+	"procs":  builtinProcs,
+	"uptime": builtinUptime,
+}
+
+// IsBuiltinDriver returns true if the supplied driver name is implemented natively.
+func IsBuiltinDriver(driver string) bool {
+	_, ok := builtinDrivers[strings.ToLower(driver)]
+
+	return ok
+}
+
+// threshold is an optional warning or critical level.
+type threshold struct {
+	value float64
+	set   bool
+}
+
+// parseThreshold parses a numeric threshold, a trailing `%` is ignored, an empty value disables the threshold.
+func parseThreshold(in string) (threshold, error) {
+	in = strings.TrimSuffix(strings.TrimSpace(in), "%")
+	if in == "" {
+		return threshold{}, nil
+	}
+
+	v, err := strconv.ParseFloat(in, 64)
+	if err != nil {
+		return threshold{}, fmt.Errorf("invalid threshold '%s': %w", in, err)
+	}
+
+	return threshold{value: v, set: true}, nil
+}
+
+// parseDurationThreshold parses a duration threshold (eg. `10m`) into seconds.
+func parseDurationThreshold(in string) (threshold, error) {
+	in = strings.TrimSpace(in)
+	if in == "" {
+		return threshold{}, nil
+	}
+
+	v, err := time.ParseDuration(in)
+	if err != nil {
+		return threshold{}, fmt.Errorf("invalid threshold '%s': %w", in, err)
+	}
+
+	return threshold{value: v.Seconds(), set: true}, nil
+}
+
+// String returns the threshold formatted for perfdata.
+func (t threshold) String() string {
+	if !t.set {
+		return ""
+	}
+
+	return formatFloat(t.value)
+}
+
+// thresholds are the warning and critical levels of a builtin check.
+type thresholds struct {
+	Warning  threshold
+	Critical threshold
+}
+
+// getThresholds parses the warning and critical thresholds of a check.
+func (i *Info) getThresholds(parse func(string) (threshold, error)) (thresholds, error) {
+	warn, err := parse(i.Warning)
+	if err != nil {
+		return thresholds{}, fmt.Errorf("warning %w", err)
+	}
+
+	crit, err := parse(i.Critical)
+	if err != nil {
+		return thresholds{}, fmt.Errorf("critical %w", err)
+	}
+
+	return thresholds{Warning: warn, Critical: crit}, nil
+}
+
+// Above returns the status of a value where higher values are worse.
+func (t thresholds) Above(v float64) api.Status {
+	switch {
+	case t.Critical.set && v >= t.Critical.value:
+		return api.Status_CRITICAL
+	case t.Warning.set && v >= t.Warning.value:
+		return api.Status_WARNING
+	default:
+		return api.Status_OK
+	}
+}
+
+// Below returns the status of a value where lower values are worse.
+func (t thresholds) Below(v float64) api.Status {
+	switch {
+	case t.Critical.set && v < t.Critical.value:
+		return api.Status_CRITICAL
+	case t.Warning.set && v < t.Warning.value:
+		return api.Status_WARNING
+	default:
+		return api.Status_OK
+	}
+}
+
+// perfdata returns a single perfdata value in the `'label'=value[UOM];[warn];[crit];[min];[max]` format.
+func (t thresholds) perfdata(label string, value float64, uom string, minValue, maxValue string) string {
+	return fmt.Sprintf("'%s'=%s%s;%s;%s;%s;%s",
+		label, formatFloat(value), uom, t.Warning, t.Critical, minValue, maxValue,
+	)
+}
+
+// worstStatus returns the most severe of the supplied statuses.
+func worstStatus(in ...api.Status) api.Status {
+	severity := map[api.Status]int{
+		api.Status_OK:       0,
+		api.Status_WARNING:  1,
+		api.Status_UNKNOWN:  2, //nolint:mnd // ordering of severity.
+		api.Status_CRITICAL: 3, //nolint:mnd // ordering of severity.
+	}
+
+	out := api.Status_OK
+
+	for _, v := range in {
+		if severity[v] > severity[out] {
+			out = v
+		}
+	}
+
+	return out
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func round2(v float64) float64 {
+	//nolint:mnd // two decimal places.
+	return float64(int64(v*100)) / 100
+}
+
+// builtinDisk checks the used percentage of each mountpoint in `paths` (default `/`).
+func builtinDisk(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := i.Paths
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+
+	statuses := []api.Status{}
+	output := []string{}
+	perfdata := []string{}
+
+	for _, path := range paths {
+		usage, err := disk.UsageWithContext(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get disk usage of '%s': %w", path, err)
+		}
+
+		pct := round2(usage.UsedPercent)
+		statuses = append(statuses, th.Above(pct))
+		output = append(output, fmt.Sprintf("%s %s%% used (%d MB free)",
+			path, formatFloat(pct), usage.Free/1024/1024, //nolint:mnd // bytes to megabytes.
+		))
+		perfdata = append(perfdata, th.perfdata(path, pct, "%", "0", "100"))
+	}
+
+	status := worstStatus(statuses...)
+
+	return &builtinResult{
+		Status:   status,
+		Output:   fmt.Sprintf("DISK %s - %s", status, strings.Join(output, ", ")),
+		Perfdata: strings.Join(perfdata, " "),
+	}, nil
+}
+
+// builtinLoad checks the 1, 5 and 15 minute load averages.
+func builtinLoad(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get load average: %w", err)
+	}
+
+	values := []struct {
+		label string
+		value float64
+	}{
+		{"load1", round2(avg.Load1)},
+		{"load5", round2(avg.Load5)},
+		{"load15", round2(avg.Load15)},
+	}
+
+	statuses := []api.Status{}
+	perfdata := []string{}
+
+	for _, v := range values {
+		statuses = append(statuses, th.Above(v.value))
+		perfdata = append(perfdata, th.perfdata(v.label, v.value, "", "0", ""))
+	}
+
+	status := worstStatus(statuses...)
+
+	return &builtinResult{
+		Status: status,
+		Output: fmt.Sprintf("LOAD %s - load average: %s, %s, %s",
+			status, formatFloat(values[0].value), formatFloat(values[1].value), formatFloat(values[2].value),
+		),
+		Perfdata: strings.Join(perfdata, " "),
+	}, nil
+}
+
+// builtinMemory checks the used percentage of physical memory.
+func builtinMemory(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get memory usage: %w", err)
+	}
+
+	pct := round2(vm.UsedPercent)
+	status := th.Above(pct)
+
+	return &builtinResult{
+		Status: status,
+		Output: fmt.Sprintf("MEMORY %s - %s%% used (%d MB of %d MB)",
+			status, formatFloat(pct), vm.Used/1024/1024, vm.Total/1024/1024, //nolint:mnd // bytes to megabytes.
+		),
+		Perfdata: th.perfdata("memory", pct, "%", "0", "100"),
+	}, nil
+}
+
+// builtinSwap checks the used percentage of swap.
+func builtinSwap(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := mem.SwapMemoryWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get swap usage: %w", err)
+	}
+
+	pct := round2(sm.UsedPercent)
+	status := th.Above(pct)
+
+	return &builtinResult{
+		Status: status,
+		Output: fmt.Sprintf("SWAP %s - %s%% used (%d MB of %d MB)",
+			status, formatFloat(pct), sm.Used/1024/1024, sm.Total/1024/1024, //nolint:mnd // bytes to megabytes.
+		),
+		Perfdata: th.perfdata("swap", pct, "%", "0", "100"),
+	}, nil
+}
+
+// builtinProcs checks the number of running processes.
+func builtinProcs(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	pids, err := process.PidsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get process list: %w", err)
+	}
+
+	count := float64(len(pids))
+	status := th.Above(count)
+
+	return &builtinResult{
+		Status:   status,
+		Output:   fmt.Sprintf("PROCS %s - %d processes", status, len(pids)),
+		Perfdata: th.perfdata("procs", count, "", "0", ""),
+	}, nil
+}
+
+// builtinUptime checks the system uptime, the thresholds are durations and it is a problem
+// when the uptime is below them (eg. the host has recently rebooted).
+func builtinUptime(ctx context.Context, i *Info) (*builtinResult, error) {
+	th, err := i.getThresholds(parseDurationThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	uptime, err := host.UptimeWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get uptime: %w", err)
+	}
+
+	status := th.Below(float64(uptime))
+
+	return &builtinResult{
+		Status: status,
+		Output: fmt.Sprintf("UPTIME %s - up %s",
+			status, (time.Duration(uptime) * time.Second).String(), //nolint:gosec // uptime fits in a duration.
+		),
+		Perfdata: th.perfdata("uptime", float64(uptime), "s", "0", ""),
+	}, nil
+}
+
+// runBuiltin runs the native implementation of the check driver.
+func (i *Info) runBuiltin(ctx context.Context) (*builtinResult, error) {
+	f, ok := builtinDrivers[strings.ToLower(i.Driver)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, i.Driver)
+	}
+
+	return f(ctx, i)
+}
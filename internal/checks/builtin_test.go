@@ -0,0 +1,62 @@
+package checks_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/checks"
+)
+
+func TestBuiltinDrivers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		driver       string
+		warning      string
+		critical     string
+		expectStatus api.Status
+		expectPrefix string
+	}{
+		{"disk without thresholds is OK", "disk", "", "", api.Status_OK, "DISK OK - / "},
+		{"disk over critical", "disk", "0", "0%", api.Status_CRITICAL, "DISK CRITICAL - / "},
+		{"load without thresholds is OK", "load", "", "", api.Status_OK, "LOAD OK - load average: "},
+		{"memory over warning", "memory", "0", "", api.Status_WARNING, "MEMORY WARNING - "},
+		{"procs over critical", "procs", "1", "1", api.Status_CRITICAL, "PROCS CRITICAL - "},
+		{"uptime below critical", "uptime", "", "87600h", api.Status_CRITICAL, "UPTIME CRITICAL - up "},
+		{"uptime above warning", "uptime", "1ns", "", api.Status_OK, "UPTIME OK - up "},
+		{"invalid threshold", "memory", "lots", "", api.Status_UNKNOWN, "MEMORY UNKNOWN - warning invalid threshold"},
+		{"unknown driver", "nope", "", "", api.Status_UNKNOWN, "NOPE UNKNOWN - unknown check driver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &checks.Info{
+				Name:     strings.ToUpper(tt.driver),
+				Timeout:  5 * time.Second,
+				Period:   10 * time.Second,
+				Driver:   tt.driver,
+				Warning:  tt.warning,
+				Critical: tt.critical,
+			}
+
+			msg := c.Run(context.Background(), time.Now())
+
+			expectCheckNotNil(t, msg)
+			expectCheckStatus(t, msg, tt.expectStatus)
+
+			if !strings.HasPrefix(msg.GetOutput(), tt.expectPrefix) {
+				t.Errorf("output, got '%s', expect prefix '%s'", msg.GetOutput(), tt.expectPrefix)
+			}
+
+			if tt.expectStatus != api.Status_UNKNOWN && msg.GetPerfdata() == "" {
+				t.Error("perfdata, got '', expect 'not empty'")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package checks
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,18 +18,118 @@ type Checks []*Info
 // NextRun sets the next run property of all checks to specified timestamp.
 func (c Checks) NextRun(t time.Time) {
 	for _, check := range c {
-		check.NextRun = t
+		check.SetNextRun(t)
 	}
 }
 
-// GetChecksFromViper gets all the checks from the viper.Viper config.
+// NextRunByName sets the next run property of the checks matching the supplied names (case-insensitive),
+// it returns the names of the checks that matched.
+func (c Checks) NextRunByName(t time.Time, names []string) []string {
+	out := []string{}
+
+	for _, check := range c {
+		for _, name := range names {
+			if strings.EqualFold(check.Name, name) {
+				check.SetNextRun(t)
+				out = append(out, check.Name)
+
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// GetByName returns the check matching the supplied name (case-insensitive).
+func (c Checks) GetByName(name string) (*Info, bool) {
+	for _, check := range c {
+		if strings.EqualFold(check.Name, name) {
+			return check, true
+		}
+	}
+
+	return nil, false
+}
+
+// Changes lists the names of the checks that differ between two check lists.
+type Changes struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// Empty returns true if there are no differences.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Merge returns the check list to use after reloading the configuration.
+//
+// Checks are matched by name, checks that are unchanged are kept from the current list so their
+// schedule and retry state are preserved, changed and added checks are taken from the new list.
+func (c Checks) Merge(in Checks) (Checks, Changes) {
+	current := make(map[string]*Info, len(c))
+	for _, check := range c {
+		current[check.Name] = check
+	}
+
+	out := make(Checks, 0, len(in))
+	changes := Changes{}
+	seen := make(map[string]bool, len(in))
+
+	for _, check := range in {
+		seen[check.Name] = true
+
+		existing, ok := current[check.Name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, check.Name)
+			out = append(out, check)
+		case !existing.Equal(check):
+			changes.Changed = append(changes.Changed, check.Name)
+			out = append(out, check)
+		default:
+			out = append(out, existing)
+		}
+	}
+
+	for _, check := range c {
+		if !seen[check.Name] {
+			changes.Removed = append(changes.Removed, check.Name)
+		}
+	}
+
+	slices.Sort(changes.Added)
+	slices.Sort(changes.Removed)
+	slices.Sort(changes.Changed)
+
+	return out, changes
+}
+
+// settingsConf is implemented by configs that can return all of their settings, eg. a config merged
+// with the files in a conf.d directory.
+type settingsConf interface {
+	AllSettings() map[string]any
+}
+
+// GetChecksFromViper gets all the checks from the viper.Viper config, when cfg can return all of its
+// settings the check names are taken from cfg so checks in conf.d files are included.
 func GetChecksFromViper(cfg config.Conf, vcfg *viper.Viper, logger *slog.Logger, hostName string) Checks {
 	checkListMap := make(map[string]bool)
 
-	for _, key := range vcfg.AllKeys() {
-		if strings.HasPrefix(key, "check.") {
-			token := strings.SplitN(key, ".", 3) //nolint:mnd // check keys come in 3 parts.
-			checkListMap[token[1]] = true
+	if sc, ok := cfg.(settingsConf); ok {
+		if v, isMap := sc.AllSettings()["check"].(map[string]any); isMap {
+			for name := range v {
+				checkListMap[name] = true
+			}
+		}
+	} else {
+		for _, key := range vcfg.AllKeys() {
+			if strings.HasPrefix(key, "check.") {
+				token := strings.SplitN(key, ".", 3) //nolint:mnd // check keys come in 3 parts.
+				checkListMap[token[1]] = true
+			}
 		}
 	}
 
@@ -48,12 +149,25 @@ func GetChecksFromViper(cfg config.Conf, vcfg *viper.Viper, logger *slog.Logger,
 // GetCheckFromViper returns a check with the specified name from the config file.
 func GetCheckFromViper(cfg config.Conf, logger *slog.Logger, name, hostName string) *Info {
 	check := &Info{
-		Name:     cfg.GetString(fmt.Sprintf("check.%s.name", name)),
-		Period:   cfg.GetDuration(fmt.Sprintf("check.%s.period", name)),
-		Command:  cfg.GetString(fmt.Sprintf("check.%s.command", name)),
-		Hostname: hostName,
-		Timeout:  cfg.GetDuration(fmt.Sprintf("check.%s.timeout", name)),
-		Workdir:  cfg.GetString(fmt.Sprintf("check.%s.workdir", name)),
+		Name:          cfg.GetString(fmt.Sprintf("check.%s.name", name)),
+		Period:        cfg.GetDuration(fmt.Sprintf("check.%s.period", name)),
+		Command:       cfg.GetString(fmt.Sprintf("check.%s.command", name)),
+		Hostname:      hostName,
+		Timeout:       cfg.GetDuration(fmt.Sprintf("check.%s.timeout", name)),
+		Workdir:       cfg.GetString(fmt.Sprintf("check.%s.workdir", name)),
+		MaxRetries:    cfg.GetInt(fmt.Sprintf("check.%s.max-retries", name)),
+		RetryInterval: cfg.GetDuration(fmt.Sprintf("check.%s.retry-interval", name)),
+		Driver:        strings.ToLower(cfg.GetString(fmt.Sprintf("check.%s.driver", name))),
+		Warning:       cfg.GetString(fmt.Sprintf("check.%s.warning", name)),
+		Critical:      cfg.GetString(fmt.Sprintf("check.%s.critical", name)),
+		Paths:         cfg.GetStringSlice(fmt.Sprintf("check.%s.paths", name)),
+	}
+
+	if check.Driver != "" && !IsBuiltinDriver(check.Driver) {
+		logger.Warn("unknown check driver, check will return UNKNOWN",
+			slog.String("check", check.Name),
+			slog.String("check-driver-supplied", check.Driver),
+		)
 	}
 
 	if check.Timeout == 0 {
@@ -64,6 +178,14 @@ func GetCheckFromViper(cfg config.Conf, logger *slog.Logger, name, hostName stri
 		check.Period = cfg.GetDuration("default.period")
 	}
 
+	if cfg.Get(fmt.Sprintf("check.%s.max-retries", name)) == nil {
+		check.MaxRetries = cfg.GetInt("default.max-retries")
+	}
+
+	if check.RetryInterval == 0 {
+		check.RetryInterval = cfg.GetDuration("default.retry-interval")
+	}
+
 	if check.Name == "" {
 		switch strings.ToLower(cfg.GetString("default.name-format")) {
 		case "lowercase", "lower", "lc":
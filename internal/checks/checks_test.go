@@ -2,13 +2,19 @@ package checks_test
 
 import (
 	"bytes"
+	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/na4ma4/config"
 	"github.com/na4ma4/rsca/api"
 	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/spf13/viper"
 )
 
 func TestLoadTestChecks(t *testing.T) {
@@ -46,7 +52,102 @@ func TestLoadTestChecks(t *testing.T) {
 		},
 	}
 
-	if diff := cmp.Diff(checkList, expectTestList, transformCheckList()); diff != "" {
+	if diff := cmp.Diff(
+		checkList, expectTestList, transformCheckList(), cmpopts.IgnoreUnexported(checks.Info{}),
+	); diff != "" {
 		t.Errorf("checks.GetChecksFromViper: check list -got +want:\n%s", diff)
 	}
 }
+
+func TestLoadTestChecksConfD(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(
+		filepath.Join(dir, "extra.toml"), []byte("[check.EXTRA]\ncommand = \"extra.sh\"\n"), 0o600,
+	); err != nil {
+		t.Fatalf("os.WriteFile(): error, got '%s', want 'nil'", err)
+	}
+
+	vcfg := viper.New()
+	vcfg.Set("check.BASE.command", "base.sh")
+
+	cfg := config.NewViperConfDFromViper(vcfg, dir, "rsca-not-used")
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	var names []string
+	for _, check := range checks.GetChecksFromViper(cfg, vcfg, logger, "localhost.localdomain") {
+		names = append(names, check.Name)
+	}
+
+	sortNames := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff([]string{"BASE", "EXTRA"}, names, sortNames); diff != "" {
+		t.Errorf("checks.GetChecksFromViper: check names -want +got:\n%s", diff)
+	}
+}
+
+func TestChecksMerge(t *testing.T) {
+	t.Parallel()
+
+	kept := &checks.Info{Name: "KEPT", Command: "kept.sh", Period: time.Minute}
+	kept.SetNextRun(time.Unix(1000, 0))
+	changed := &checks.Info{Name: "CHANGED", Command: "changed.sh", Period: time.Minute}
+	removed := &checks.Info{Name: "REMOVED", Command: "removed.sh", Period: time.Minute}
+
+	current := checks.Checks{kept, changed, removed}
+	reloaded := checks.Checks{
+		&checks.Info{Name: "KEPT", Command: "kept.sh", Period: time.Minute},
+		&checks.Info{Name: "CHANGED", Command: "changed.sh", Period: time.Hour},
+		&checks.Info{Name: "ADDED", Command: "added.sh", Period: time.Minute},
+	}
+
+	merged, changes := current.Merge(reloaded)
+
+	expectChanges := checks.Changes{
+		Added:   []string{"ADDED"},
+		Removed: []string{"REMOVED"},
+		Changed: []string{"CHANGED"},
+	}
+
+	if diff := cmp.Diff(changes, expectChanges); diff != "" {
+		t.Errorf("checks.Merge: changes -got +want:\n%s", diff)
+	}
+
+	if len(merged) != len(reloaded) {
+		t.Fatalf("checks.Merge: merged length got '%d', want '%d'", len(merged), len(reloaded))
+	}
+
+	if merged[0] != kept {
+		t.Error("checks.Merge: unchanged check should be kept from the current list")
+	}
+
+	if merged[1] != reloaded[1] || merged[2] != reloaded[2] {
+		t.Error("checks.Merge: changed and added checks should be taken from the reloaded list")
+	}
+
+	if _, changes := merged.Merge(merged); !changes.Empty() {
+		t.Errorf("checks.Merge: merging identical lists should have no changes, got '%+v'", changes)
+	}
+}
+
+func TestChecksNextRunByName(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Unix(1000, 0)
+	checkList := checks.Checks{
+		&checks.Info{Name: "DISK"},
+		&checks.Info{Name: "LOAD"},
+		&checks.Info{Name: "MEMORY"},
+	}
+	checkList.NextRun(ts)
+
+	triggered := checkList.NextRunByName(time.Time{}, []string{"disk", "LOAD", "SWAP"})
+
+	if diff := cmp.Diff(triggered, []string{"DISK", "LOAD"}); diff != "" {
+		t.Errorf("checks.NextRunByName: triggered -got +want:\n%s", diff)
+	}
+
+	if !checkList[0].NextRun().IsZero() || !checkList[1].NextRun().IsZero() || !checkList[2].NextRun().Equal(ts) {
+		t.Error("checks.NextRunByName: only the named checks should be rescheduled")
+	}
+}
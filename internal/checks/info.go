@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"os/exec"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
@@ -20,19 +21,90 @@ import (
 	"github.com/na4ma4/rsca/api"
 	"github.com/spf13/viper"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Info is the details of a check.
 type Info struct {
-	Name     string
-	Type     api.CheckType
-	Hostname string
-	Period   time.Duration
-	NextRun  time.Time
-	Command  string
-	Timeout  time.Duration
-	Workdir  string
+	Name          string
+	Type          api.CheckType
+	Hostname      string
+	Period        time.Duration
+	Command       string
+	Timeout       time.Duration
+	Workdir       string
+	MaxRetries    int
+	RetryInterval time.Duration
+
+	// Driver selects a builtin check instead of running Command, Warning, Critical and Paths
+	// are the settings used by the builtin check.
+	Driver   string
+	Warning  string
+	Critical string
+	Paths    []string
+
+	// failures is the number of consecutive non-OK results, capped at MaxRetries.
+	failures int
+
+	// nextRun is the time the check is next due, it is written by the scheduler workers and the
+	// client so it is only accessed while holding lock.
+	nextRun time.Time
+	lock    sync.Mutex
+}
+
+// NextRun returns the time the check is next due.
+func (i *Info) NextRun() time.Time {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.nextRun
+}
+
+// SetNextRun sets the time the check is next due.
+func (i *Info) SetNextRun(t time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.nextRun = t
+}
+
+// Equal returns true if the configuration of both checks is the same, the schedule and retry state
+// are not compared.
+func (i *Info) Equal(o *Info) bool {
+	return i.Name == o.Name &&
+		i.Type == o.Type &&
+		i.Hostname == o.Hostname &&
+		i.Period == o.Period &&
+		i.Command == o.Command &&
+		i.Timeout == o.Timeout &&
+		i.Workdir == o.Workdir &&
+		i.MaxRetries == o.MaxRetries &&
+		i.RetryInterval == o.RetryInterval &&
+		i.Driver == o.Driver &&
+		i.Warning == o.Warning &&
+		i.Critical == o.Critical &&
+		slices.Equal(i.Paths, o.Paths)
+}
+
+// Clone returns a copy of the check configuration without the schedule and retry state, it is used
+// to run a check on demand without affecting the scheduled check.
+func (i *Info) Clone() *Info {
+	return &Info{
+		Name:          i.Name,
+		Type:          i.Type,
+		Hostname:      i.Hostname,
+		Period:        i.Period,
+		Command:       i.Command,
+		Timeout:       i.Timeout,
+		Workdir:       i.Workdir,
+		MaxRetries:    i.MaxRetries,
+		RetryInterval: i.RetryInterval,
+		Driver:        i.Driver,
+		Warning:       i.Warning,
+		Critical:      i.Critical,
+		Paths:         slices.Clone(i.Paths),
+	}
 }
 
 // runCmd runs a supplied command and returns the exitcode.
@@ -116,8 +188,6 @@ func (i *Info) ioCopyWaitGroup(wg *sync.WaitGroup, dst io.Writer, src io.Reader)
 
 // Run executes a check and returns an api.EventMessage with the details.
 func (i *Info) Run(ctx context.Context, t time.Time) *api.EventMessage {
-	args := i.splitCmd()
-
 	if i.Timeout > 0 {
 		var cancel context.CancelFunc
 
@@ -125,18 +195,52 @@ func (i *Info) Run(ctx context.Context, t time.Time) *api.EventMessage {
 		defer cancel()
 	}
 
-	exitCode, ob, oberr, err := i.wrapCmd(ctx, args)
-	status := api.ExitCodeToStatus(exitCode)
 	resp := api.EventMessage_builder{
 		Check:            proto.String(i.Name),
 		Hostname:         proto.String(i.Hostname),
 		Type:             &i.Type,
 		Id:               proto.String(uuid.New().String()),
-		Output:           proto.String(strings.TrimSpace(ob.String())),
-		Status:           &status,
 		RequestTimestamp: timestamppb.New(t),
 	}.Build()
 
+	start := time.Now()
+
+	if i.Driver != "" {
+		i.runDriver(ctx, resp)
+	} else {
+		i.runCommand(ctx, resp)
+	}
+
+	resp.SetDuration(durationpb.New(time.Since(start)))
+
+	i.updateRetries(resp)
+
+	if resp.IsSoftState() && i.RetryInterval > 0 {
+		i.SetNextRun(time.Now().Add(i.RetryInterval))
+	} else if viper.GetDuration("general.jitter").Seconds() > 1 {
+		// don't care about how secure the random is, it's for jitter calculations
+		//nolint:gosec // basic random is good enough.
+		checkJitter := time.Duration(
+			rand.IntN(int(viper.GetDuration("general.jitter").Seconds())),
+		) * time.Second
+		i.SetNextRun(time.Now().Add(i.Period).Add(checkJitter))
+	} else {
+		i.SetNextRun(time.Now().Add(i.Period))
+	}
+
+	return resp
+}
+
+// runCommand executes the check command and fills in the result.
+func (i *Info) runCommand(ctx context.Context, resp *api.EventMessage) {
+	exitCode, ob, oberr, err := i.wrapCmd(ctx, i.splitCmd())
+	output, longOutput, perfdata := ParseOutput(ob.String())
+
+	resp.SetStatus(api.ExitCodeToStatus(exitCode))
+	resp.SetOutput(output)
+	resp.SetLongOutput(longOutput)
+	resp.SetPerfdata(perfdata)
+
 	switch {
 	case errors.Is(ctx.Err(), context.DeadlineExceeded):
 		resp.SetOutputError("check timeout")
@@ -146,45 +250,52 @@ func (i *Info) Run(ctx context.Context, t time.Time) *api.EventMessage {
 	default:
 		resp.SetOutputError(strings.TrimSpace(oberr.String()))
 	}
+}
 
-	if viper.GetDuration("general.jitter").Seconds() > 1 {
-		// don't care about how secure the random is, it's for jitter calculations
-		//nolint:gosec // basic random is good enough.
-		checkJitter := time.Duration(
-			rand.IntN(int(viper.GetDuration("general.jitter").Seconds())),
-		) * time.Second
-		i.NextRun = time.Now().Add(i.Period).Add(checkJitter)
-	} else {
-		i.NextRun = time.Now().Add(i.Period)
+// runDriver runs the builtin check driver and fills in the result.
+func (i *Info) runDriver(ctx context.Context, resp *api.EventMessage) {
+	res, err := i.runBuiltin(ctx)
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		resp.SetOutputError("check timeout")
+		resp.SetStatus(api.Status_UNKNOWN)
+	case err != nil:
+		resp.SetOutput(fmt.Sprintf("%s UNKNOWN - %s", strings.ToUpper(i.Driver), err))
+		resp.SetOutputError(err.Error())
+		resp.SetStatus(api.Status_UNKNOWN)
+	default:
+		resp.SetStatus(res.Status)
+		resp.SetOutput(res.Output)
+		resp.SetPerfdata(res.Perfdata)
+	}
+}
+
+// updateRetries records the result against the count of consecutive failures and fills in the retry
+// details so the server can tell soft and hard states apart.
+func (i *Info) updateRetries(resp *api.EventMessage) {
+	if resp.GetStatus() == api.Status_OK {
+		i.failures = 0
 	}
 
-	return resp
+	//nolint:gosec // MaxRetries is a small configured value.
+	resp.SetRetries(int32(i.failures))
+	//nolint:gosec // MaxRetries is a small configured value.
+	resp.SetMaxRetries(int32(i.MaxRetries))
+
+	if resp.GetStatus() != api.Status_OK && i.failures < i.MaxRetries {
+		i.failures++
+	}
 }
 
-// RunChecks is a routine that will cycle through the checks on a schedule and execute any pending checks.
+// RunChecks is a routine that will cycle through the checks on a schedule and execute any pending checks
+// on a pool of `general.max-concurrent-checks` workers.
 func RunChecks(
 	ctx context.Context,
 	cfg config.Conf,
-	_ *slog.Logger,
+	logger *slog.Logger,
 	checkList []*Info,
 	respChan chan *api.EventMessage,
 ) func() error {
-	ticker := time.NewTicker(cfg.GetDuration("general.check-tick"))
-
-	return func() error {
-		for {
-			select {
-			case <-ctx.Done():
-				ticker.Stop()
-
-				return nil
-			case t := <-ticker.C:
-				for i := range checkList {
-					if t.After(checkList[i].NextRun) {
-						respChan <- checkList[i].Run(ctx, t)
-					}
-				}
-			}
-		}
-	}
+	return NewScheduler(cfg, logger, checkList, respChan).Run(ctx)
 }
@@ -151,3 +151,35 @@ func TestCheckRun(t *testing.T) {
 		t.Fatal("test didn't finish in time")
 	}
 }
+
+func TestCheckSoftState(t *testing.T) {
+	t.Parallel()
+
+	c := generateCheck("SERVICE_WARNING", "testdata/check_warning.sh")
+	c.MaxRetries = 2
+	c.RetryInterval = time.Second
+	ctx := context.Background()
+
+	for attempt, expectSoft := range []bool{true, true, false, false} {
+		ts := time.Now()
+		msg := c.Run(ctx, ts)
+
+		expectCheckNotNil(t, msg)
+		expectCheckStatus(t, msg, api.Status_WARNING)
+
+		if msg.IsSoftState() != expectSoft {
+			t.Errorf("attempt %d: IsSoftState() got '%t', want '%t' (retries %d/%d)",
+				attempt, msg.IsSoftState(), expectSoft, msg.GetRetries(), msg.GetMaxRetries())
+		}
+
+		if expectSoft && !c.NextRun().Before(ts.Add(c.Period)) {
+			t.Errorf("attempt %d: soft state should be rescheduled at the retry interval", attempt)
+		}
+	}
+
+	c.Command = "testdata/check_ok.sh"
+
+	if msg := c.Run(ctx, time.Now()); msg.GetRetries() != 0 || msg.IsSoftState() {
+		t.Errorf("OK result should reset retries, got retries '%d'", msg.GetRetries())
+	}
+}
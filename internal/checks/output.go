@@ -0,0 +1,54 @@
+package checks
+
+import "strings"
+
+// ParseOutput splits the output of a Nagios plugin into the first line of text output, the
+// long text output and the performance data.
+//
+// The plugin output format is:
+//
+//	TEXT OUTPUT | OPTIONAL PERFDATA
+//	LONG TEXT LINE 1
+//	LONG TEXT LINE 2
+//	...
+//	LONG TEXT LINE N | PERFDATA LINE 2
+//	PERFDATA LINE 3
+//	...
+func ParseOutput(in string) (string, string, string) {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(in), "\r\n", "\n"), "\n")
+
+	output, perf, _ := strings.Cut(lines[0], "|")
+	perfdata := []string{}
+	longOutput := []string{}
+
+	if v := strings.TrimSpace(perf); v != "" {
+		perfdata = append(perfdata, v)
+	}
+
+	inPerfdata := false
+
+	for _, line := range lines[1:] {
+		if inPerfdata {
+			if v := strings.TrimSpace(line); v != "" {
+				perfdata = append(perfdata, v)
+			}
+
+			continue
+		}
+
+		text, perf, found := strings.Cut(line, "|")
+		longOutput = append(longOutput, strings.TrimRight(text, " \t"))
+
+		if found {
+			inPerfdata = true
+
+			if v := strings.TrimSpace(perf); v != "" {
+				perfdata = append(perfdata, v)
+			}
+		}
+	}
+
+	return strings.TrimSpace(output),
+		strings.TrimSpace(strings.Join(longOutput, "\n")),
+		strings.Join(perfdata, " ")
+}
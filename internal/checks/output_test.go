@@ -0,0 +1,70 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/na4ma4/rsca/internal/checks"
+)
+
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		input            string
+		expectOutput     string
+		expectLongOutput string
+		expectPerfdata   string
+	}{
+		{
+			"text only", "DISK OK\n",
+			"DISK OK", "", "",
+		},
+		{
+			"text and perfdata", "DISK OK - free space: / 3326 MB | /=2643MB;5948;5958;0;5968\n",
+			"DISK OK - free space: / 3326 MB", "", "/=2643MB;5948;5958;0;5968",
+		},
+		{
+			"long output without perfdata", "DISK OK\n/ 15272 MB (77%);\n/boot 68 MB (69%);",
+			"DISK OK", "/ 15272 MB (77%);\n/boot 68 MB (69%);", "",
+		},
+		{
+			"long output with trailing perfdata",
+			"DISK OK - free space: / 3326 MB (56%); | /=2643MB;5948;5958;0;5968\n" +
+				"/ 15272 MB (77%);\n" +
+				"/boot 68 MB (69%);\n" +
+				"/home 69357 MB (27%);\n" +
+				"/var/log 819 MB (84%); | /boot=68MB;88;93;0;98\n" +
+				"/home=69357MB;253404;253409;0;253414 \n" +
+				"/var/log=818MB;970;975;0;980\n",
+			"DISK OK - free space: / 3326 MB (56%);",
+			"/ 15272 MB (77%);\n/boot 68 MB (69%);\n/home 69357 MB (27%);\n/var/log 819 MB (84%);",
+			"/=2643MB;5948;5958;0;5968 /boot=68MB;88;93;0;98 /home=69357MB;253404;253409;0;253414 " +
+				"/var/log=818MB;970;975;0;980",
+		},
+		{
+			"empty", "",
+			"", "", "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, longOutput, perfdata := checks.ParseOutput(tt.input)
+
+			if output != tt.expectOutput {
+				t.Errorf("checks.ParseOutput(): output got '%s', expect '%s'", output, tt.expectOutput)
+			}
+
+			if longOutput != tt.expectLongOutput {
+				t.Errorf("checks.ParseOutput(): long output got '%s', expect '%s'", longOutput, tt.expectLongOutput)
+			}
+
+			if perfdata != tt.expectPerfdata {
+				t.Errorf("checks.ParseOutput(): perfdata got '%s', expect '%s'", perfdata, tt.expectPerfdata)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package checks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+)
+
+// Scheduler cycles through the checks on a schedule and dispatches any pending checks to a
+// bounded pool of workers, a check is never started again while it is still running.
+type Scheduler struct {
+	Logger   *slog.Logger
+	checks   Checks
+	respChan chan *api.EventMessage
+	tick     time.Duration
+	workers  int
+	reload   chan schedulerReload
+}
+
+// schedulerReload is a request to replace the check list of a running scheduler.
+type schedulerReload struct {
+	checks Checks
+	done   chan Checks
+}
+
+// schedulerJob is a check dispatched to a worker.
+type schedulerJob struct {
+	check *Info
+	ts    time.Time
+}
+
+// schedulerResult is returned by a worker when a check has completed.
+type schedulerResult struct {
+	check    *Info
+	duration time.Duration
+}
+
+// NewScheduler returns a Scheduler for the supplied checks, results are sent to respChan.
+func NewScheduler(
+	cfg config.Conf,
+	logger *slog.Logger,
+	checkList Checks,
+	respChan chan *api.EventMessage,
+) *Scheduler {
+	workers := cfg.GetInt("general.max-concurrent-checks")
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Scheduler{
+		Logger:   logger,
+		checks:   checkList,
+		respChan: respChan,
+		tick:     cfg.GetDuration("general.check-tick"),
+		workers:  workers,
+		reload:   make(chan schedulerReload),
+	}
+}
+
+// Reload replaces the check list of the running scheduler and returns the list now in use.
+//
+// Unchanged checks keep their schedule, checks that are in progress are allowed to finish and
+// removed checks are not run again.
+func (s *Scheduler) Reload(ctx context.Context, checkList Checks) Checks {
+	req := schedulerReload{checks: checkList, done: make(chan Checks, 1)}
+
+	select {
+	case s.reload <- req:
+	case <-ctx.Done():
+		return nil
+	}
+
+	select {
+	case out := <-req.done:
+		return out
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// Run is a routine that runs the scheduler and worker pool until the context is cancelled.
+//
+//nolint:gocognit // I don't see an easy way to make this less complex without making it less maintainable.
+func (s *Scheduler) Run(ctx context.Context) func() error {
+	ticker := time.NewTicker(s.tick)
+
+	return func() error {
+		jobs := make(chan schedulerJob)
+		done := make(chan schedulerResult)
+
+		for range s.workers {
+			go s.worker(ctx, jobs, done)
+		}
+
+		queue := []schedulerJob{}
+		// running maps the checks that are in progress to the time they are next due.
+		running := map[*Info]time.Time{}
+		overrun := map[*Info]bool{}
+
+		for {
+			var (
+				next chan<- schedulerJob
+				head schedulerJob
+			)
+
+			if len(queue) > 0 {
+				next = jobs
+				head = queue[0]
+			}
+
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+
+				return nil
+			case t := <-ticker.C:
+				for _, check := range s.checks {
+					// the check is not queued again until it has finished, even if it is triggered.
+					if due, ok := running[check]; ok {
+						if t.After(due) && !overrun[check] {
+							overrun[check] = true
+
+							s.Logger.WarnContext(ctx, "check is still running and has overrun its period",
+								slog.String("check.name", check.Name),
+								slog.Duration("check.period", check.Period),
+							)
+						}
+
+						continue
+					}
+
+					if !t.After(check.NextRun()) {
+						continue
+					}
+
+					running[check] = t.Add(check.Period)
+
+					queue = append(queue, schedulerJob{check: check, ts: t})
+				}
+			case req := <-s.reload:
+				merged, changes := s.checks.Merge(req.checks)
+				s.checks = merged
+
+				s.Logger.InfoContext(ctx, "reloaded checks",
+					slog.Any("checks.added", changes.Added),
+					slog.Any("checks.removed", changes.Removed),
+					slog.Any("checks.changed", changes.Changed),
+				)
+
+				req.done <- merged
+			case next <- head:
+				queue = queue[1:]
+			case r := <-done:
+				delete(running, r.check)
+				delete(overrun, r.check)
+
+				if r.check.Period > 0 && r.duration > r.check.Period {
+					s.Logger.WarnContext(ctx, "check took longer than its period to run",
+						slog.String("check.name", r.check.Name),
+						slog.Duration("check.period", r.check.Period),
+						slog.Duration("check.duration", r.duration),
+					)
+				}
+			}
+		}
+	}
+}
+
+// worker runs the checks dispatched by the scheduler and sends the results on.
+func (s *Scheduler) worker(ctx context.Context, jobs <-chan schedulerJob, done chan<- schedulerResult) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-jobs:
+			start := time.Now()
+			resp := job.check.Run(ctx, job.ts)
+
+			select {
+			case s.respChan <- resp:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case done <- schedulerResult{check: job.check, duration: time.Since(start)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
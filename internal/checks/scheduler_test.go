@@ -0,0 +1,113 @@
+package checks_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/spf13/viper"
+)
+
+func TestSchedulerSlowCheckDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	vcfg := viper.New()
+	timeout := time.After(3 * time.Second)
+
+	vcfg.Set("general.check-tick", "1ms")
+	vcfg.Set("general.max-concurrent-checks", 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	checkList := checks.Checks{
+		generateCheck("SLEEP_TEST", "testdata/check_sleep.sh"),
+		generateCheck("TEST", "testdata/check_ok.sh"),
+	}
+	respChan := make(chan *api.EventMessage)
+
+	go func(f func() error) {
+		if err := f(); err != nil {
+			t.Errorf("checks.Scheduler.Run(): error, got '%s', want 'nil'", err)
+		}
+	}(checks.NewScheduler(cfg, logger, checkList, respChan).Run(ctx))
+
+	select {
+	case respEvent := <-respChan:
+		expectCheckNotNil(t, respEvent)
+		expectCheckStatus(t, respEvent, api.Status_OK)
+		expectCheckOutput(t, respEvent, "Test All OK")
+	case <-timeout:
+		t.Fatal("fast check was blocked by slow check")
+	}
+}
+
+func TestSchedulerReload(t *testing.T) {
+	t.Parallel()
+
+	vcfg := viper.New()
+	vcfg.Set("general.check-tick", "1ms")
+	vcfg.Set("general.max-concurrent-checks", 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	removed := generateCheck("REMOVED", "testdata/check_ok.sh")
+	removed.Period = 10 * time.Millisecond
+	added := generateCheck("ADDED", "testdata/check_warning.sh")
+	added.Period = 10 * time.Millisecond
+
+	respChan := make(chan *api.EventMessage)
+	scheduler := checks.NewScheduler(cfg, logger, checks.Checks{removed}, respChan)
+
+	go func() { _ = scheduler.Run(ctx)() }()
+
+	if got := (<-respChan).GetCheck(); got != "REMOVED" {
+		t.Fatalf("checks.Scheduler.Run(): check got '%s', want 'REMOVED'", got)
+	}
+
+	resultChan := make(chan checks.Checks, 1)
+	go func() { resultChan <- scheduler.Reload(ctx, checks.Checks{added}) }()
+
+	// results from the removed check are drained until the reload has been applied.
+	var checkList checks.Checks
+	for checkList == nil {
+		select {
+		case <-respChan:
+		case checkList = <-resultChan:
+		case <-ctx.Done():
+			t.Fatal("checks.Scheduler.Reload(): timed out")
+		}
+	}
+
+	if len(checkList) != 1 || checkList[0].Name != "ADDED" {
+		t.Fatalf("checks.Scheduler.Reload(): check list got '%v', want '[ADDED]'", checkList)
+	}
+
+	// a run of the removed check already in progress is allowed to finish, it is not run again.
+	counts := map[string]int{}
+	for counts["ADDED"] < 3 {
+		select {
+		case resp := <-respChan:
+			counts[resp.GetCheck()]++
+		case <-ctx.Done():
+			t.Fatalf("checks.Scheduler.Run(): timed out, got '%v'", counts)
+		}
+	}
+
+	if counts["REMOVED"] > 1 {
+		t.Errorf("checks.Scheduler.Run(): removed check results got '%d', want at most '1'", counts["REMOVED"])
+	}
+}
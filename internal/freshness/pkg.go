@@ -0,0 +1,3 @@
+// Package freshness tracks the time of the last check result of each service so services that
+// have stopped reporting can be detected.
+package freshness
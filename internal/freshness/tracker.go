@@ -0,0 +1,130 @@
+package freshness
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Service is a service that has not reported a result within the allowed number of periods.
+type Service struct {
+	Hostname   string
+	Name       string
+	LastResult time.Time
+	Period     time.Duration
+}
+
+// key identifies a service, hostnames and service names are not case sensitive.
+type key struct {
+	hostname string
+	service  string
+}
+
+func newKey(hostname, service string) key {
+	return key{hostname: strings.ToLower(hostname), service: strings.ToLower(service)}
+}
+
+// entry is the state of a tracked service.
+type entry struct {
+	hostname   string
+	service    string
+	lastResult time.Time
+	period     time.Duration
+	stale      bool
+}
+
+// Tracker records the last result time and check period of each service.
+type Tracker struct {
+	lock     sync.Mutex
+	services map[key]*entry
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		services: map[key]*entry{},
+	}
+}
+
+// Seen records a result for the service, a stale service becomes fresh again.
+func (t *Tracker) Seen(hostname, service string, ts time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	e := t.entry(hostname, service, ts)
+	e.lastResult = ts
+	e.stale = false
+}
+
+// SetPeriods records the check periods advertised by a host, services that have not been seen
+// before are tracked from now.
+//
+// Services missing from periods keep their last known period, so a check that is removed from the
+// host configuration is still reported as stale.
+func (t *Tracker) SetPeriods(hostname string, periods map[string]time.Duration, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for service, period := range periods {
+		t.entry(hostname, service, now).period = period
+	}
+}
+
+// Retain stops tracking the services of hosts that are not in hostnames.
+func (t *Tracker) Retain(hostnames []string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for k := range t.services {
+		if !slices.ContainsFunc(hostnames, func(v string) bool { return strings.EqualFold(v, k.hostname) }) {
+			delete(t.services, k)
+		}
+	}
+}
+
+// Stale returns the services that have become stale since the last call, a service is stale when
+// no result has been received for the number of check periods.
+func (t *Tracker) Stale(now time.Time, periods int) []Service {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	out := []Service{}
+
+	for _, e := range t.services {
+		if e.stale || e.period <= 0 || now.Sub(e.lastResult) <= e.period*time.Duration(periods) {
+			continue
+		}
+
+		e.stale = true
+		out = append(out, Service{
+			Hostname:   e.hostname,
+			Name:       e.service,
+			LastResult: e.lastResult,
+			Period:     e.period,
+		})
+	}
+
+	slices.SortFunc(out, func(a, b Service) int {
+		if c := strings.Compare(a.Hostname, b.Hostname); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return out
+}
+
+// entry returns the entry for the service, creating it with a last result time of ts.
+func (t *Tracker) entry(hostname, service string, ts time.Time) *entry {
+	k := newKey(hostname, service)
+
+	e, ok := t.services[k]
+	if !ok {
+		e = &entry{hostname: hostname, service: service, lastResult: ts}
+		t.services[k] = e
+	}
+
+	return e
+}
@@ -0,0 +1,52 @@
+package freshness_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/internal/freshness"
+)
+
+func TestTrackerStale(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1700000000, 0)
+	tr := freshness.NewTracker()
+
+	tr.SetPeriods("web01", map[string]time.Duration{"DISK": time.Minute, "LOAD": time.Minute}, start)
+	tr.SetPeriods("web02", map[string]time.Duration{"DISK": time.Minute}, start)
+	tr.Seen("WEB01", "disk", start.Add(2*time.Minute))
+	tr.Seen("nsca-only", "BACKUP", start)
+
+	if got := tr.Stale(start.Add(3*time.Minute), 3); len(got) != 0 {
+		t.Errorf("Tracker.Stale(3m): got '%v', want none", got)
+	}
+
+	want := []freshness.Service{
+		{Hostname: "web01", Name: "LOAD", LastResult: start, Period: time.Minute},
+		{Hostname: "web02", Name: "DISK", LastResult: start, Period: time.Minute},
+	}
+
+	if diff := cmp.Diff(want, tr.Stale(start.Add(4*time.Minute), 3)); diff != "" {
+		t.Errorf("Tracker.Stale(4m): -want +got:\n%s", diff)
+	}
+
+	// stale services are only returned once.
+	if got := tr.Stale(start.Add(5*time.Minute), 3); len(got) != 0 {
+		t.Errorf("Tracker.Stale(5m): got '%v', want none", got)
+	}
+
+	// a removed check keeps its period and still goes stale, a result makes it fresh again.
+	tr.SetPeriods("web01", map[string]time.Duration{"LOAD": time.Minute}, start)
+	tr.Seen("web02", "DISK", start.Add(5*time.Minute))
+	tr.Retain([]string{"web01"})
+
+	want = []freshness.Service{
+		{Hostname: "web01", Name: "DISK", LastResult: start.Add(2 * time.Minute), Period: time.Minute},
+	}
+
+	if diff := cmp.Diff(want, tr.Stale(start.Add(10*time.Minute), 3)); diff != "" {
+		t.Errorf("Tracker.Stale(10m): -want +got:\n%s", diff)
+	}
+}
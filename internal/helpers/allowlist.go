@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrInvalidAllow is returned when an entry in an allow list is not an IP address or CIDR range.
+var ErrInvalidAllow = errors.New("invalid allow address")
+
+// AllowList is a list of networks that are allowed to connect to a listener.
+type AllowList []*net.IPNet
+
+// ParseAllowList parses a list of IP addresses and CIDR ranges.
+func ParseAllowList(in []string) (AllowList, error) {
+	out := AllowList{}
+
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidAllow, v)
+			}
+
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}) //nolint:mnd // bits.
+
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidAllow, err)
+		}
+
+		out = append(out, n)
+	}
+
+	return out, nil
+}
+
+// IsAllowed returns true if the remote address is in the allow list.
+func (a AllowList) IsAllowed(addr net.Addr) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	for _, n := range a {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// backoffMultiplier is the growth factor applied to the delay after each failed attempt.
+const backoffMultiplier = 2
+
+// Backoff calculates exponentially increasing delays with full jitter between a minimum and maximum.
+type Backoff struct {
+	Min     time.Duration
+	Max     time.Duration
+	attempt int
+}
+
+// NewBackoff returns a Backoff that starts at minDelay and is capped at maxDelay.
+func NewBackoff(minDelay, maxDelay time.Duration) *Backoff {
+	if minDelay <= 0 {
+		minDelay = time.Second
+	}
+
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	return &Backoff{
+		Min: minDelay,
+		Max: maxDelay,
+	}
+}
+
+// Next returns the delay to wait before the next attempt and advances the attempt counter.
+//
+// The returned delay is a random duration between Min and the current exponential ceiling,
+// this spreads reconnecting clients out so they don't all arrive at the server at once.
+func (b *Backoff) Next() time.Duration {
+	ceiling := b.Min
+
+	for range b.attempt {
+		ceiling *= backoffMultiplier
+		if ceiling >= b.Max {
+			ceiling = b.Max
+
+			break
+		}
+	}
+
+	b.attempt++
+
+	if ceiling <= b.Min {
+		return b.Min
+	}
+
+	// don't care about how secure the random is, it's for jitter calculations
+	//nolint:gosec // basic random is good enough.
+	return b.Min + time.Duration(rand.Int64N(int64(ceiling-b.Min)))
+}
+
+// Reset sets the attempt counter back to zero after a successful connection.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/na4ma4/go-permbits"
+)
+
+// unixPrefix is the prefix of listen addresses that are unix sockets.
+const unixPrefix = "unix:"
+
+// Listen listens on a TCP address, or a unix socket when the address has the `unix:` prefix (eg.
+// `unix:/run/rsca/admin.sock`). A stale unix socket is removed and the new socket is only
+// accessible by the user and group.
+func Listen(ctx context.Context, address string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(address, unixPrefix)
+	if !ok {
+		lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", address, err)
+		}
+
+		return lis, nil
+	}
+
+	// remove a stale socket left behind by a previous process.
+	if st, err := os.Lstat(path); err == nil && st.Mode()&os.ModeSocket != 0 {
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("unable to remove existing socket: %w", err)
+		}
+	}
+
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %w", path, err)
+	}
+
+	if err = os.Chmod(path, permbits.MustString("ug=rw")); err != nil {
+		_ = lis.Close()
+
+		return nil, fmt.Errorf("unable to set permissions on socket: %w", err)
+	}
+
+	return lis, nil
+}
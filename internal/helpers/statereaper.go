@@ -11,12 +11,14 @@ import (
 	"github.com/na4ma4/rsca/internal/state"
 )
 
-// StateReaper periodically checks the state store and deactivates old entries.
+// StateReaper periodically checks the state store and deactivates old entries, deactivated is called
+// with the hostname of each entry that is deactivated.
 func StateReaper(
 	ctx context.Context,
 	cfg config.Conf,
 	logger *slog.Logger,
 	st state.State,
+	deactivated func(ctx context.Context, hostname, reason string),
 ) func() error {
 	logger.InfoContext(ctx, "starting state reaper")
 
@@ -55,7 +57,11 @@ func StateReaper(
 
 					if err := st.DeactivateByHostname(expireState[k]); err != nil {
 						logger.ErrorContext(ctx, "unable to deactive member", slogtool.ErrorAttr(err))
+
+						continue
 					}
+
+					deactivated(ctx, expireState[k], "inactive")
 				}
 			case <-ctx.Done():
 				logger.DebugContext(ctx, "StateReaper Done()")
@@ -0,0 +1,81 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Identity is the names of a verified client certificate.
+type Identity struct {
+	CommonName string
+	DNSNames   []string
+}
+
+// FromCertificate returns the Identity of a certificate.
+func FromCertificate(cert *x509.Certificate) Identity {
+	return Identity{
+		CommonName: cert.Subject.CommonName,
+		DNSNames:   cert.DNSNames,
+	}
+}
+
+// FromContext returns the Identity of the client certificate of the gRPC peer, false is returned
+// if the peer did not present a certificate.
+func FromContext(ctx context.Context) (Identity, bool) {
+	cert, ok := CertificateFromContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+
+	return FromCertificate(cert), true
+}
+
+// CertificateFromContext returns the client certificate of the gRPC peer, false is returned if the
+// peer did not present a certificate.
+func CertificateFromContext(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return nil, false
+	}
+
+	return info.State.PeerCertificates[0], true
+}
+
+// Fingerprint returns the lowercase hex SHA-256 fingerprint of the certificate.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Names returns the common name and DNS names of the certificate without duplicates.
+func (i Identity) Names() []string {
+	out := []string{}
+
+	for _, name := range append([]string{i.CommonName}, i.DNSNames...) {
+		if name == "" || slices.ContainsFunc(out, func(v string) bool { return strings.EqualFold(v, name) }) {
+			continue
+		}
+
+		out = append(out, name)
+	}
+
+	return out
+}
+
+// String returns the names of the certificate for logging.
+func (i Identity) String() string {
+	return strings.Join(i.Names(), ",")
+}
@@ -0,0 +1,271 @@
+package identity
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/na4ma4/config"
+)
+
+// Modes that match the certificate identity to a hostname.
+const (
+	// ModeExact matches a hostname that is one of the certificate names.
+	ModeExact = "exact"
+	// ModeSuffix matches a hostname that is one of the certificate names with or without the
+	// `identity.suffix` domain.
+	ModeSuffix = "suffix"
+	// ModeRegex matches a hostname that is the first submatch (or the match) of `identity.regex`
+	// against one of the certificate names.
+	ModeRegex = "regex"
+	// ModeAllowList matches a hostname that is listed for one of the certificate names in the
+	// `identity.allowlist-file`.
+	ModeAllowList = "allowlist"
+)
+
+// Actions taken when a hostname does not match the certificate identity.
+const (
+	// ActionReject rejects the registration or check result.
+	ActionReject = "reject"
+	// ActionRewrite replaces the hostname with the hostname of the certificate identity.
+	ActionRewrite = "rewrite"
+)
+
+var (
+	// ErrUnknownMode is returned when `identity.mode` is not a known mode.
+	ErrUnknownMode = errors.New("unknown identity mode")
+
+	// ErrUnknownAction is returned when `identity.action` is not a known action.
+	ErrUnknownAction = errors.New("unknown identity action")
+
+	// ErrMissingOption is returned when an option required by the mode is not set.
+	ErrMissingOption = errors.New("missing identity option")
+
+	// ErrInvalidAllowList is returned when a line of the allowlist file can not be parsed.
+	ErrInvalidAllowList = errors.New("invalid identity allowlist")
+)
+
+// Decision is the result of enforcing the policy on a hostname.
+type Decision int
+
+const (
+	// Allowed is a hostname that matches the certificate identity.
+	Allowed Decision = iota
+	// Rewritten is a hostname that did not match and is replaced with the identity hostname.
+	Rewritten
+	// Rejected is a hostname that did not match and is rejected.
+	Rejected
+)
+
+// String returns the name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case Allowed:
+		return "allowed"
+	case Rewritten:
+		return "rewritten"
+	case Rejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// Policy binds the hostnames a client can use to the identity of its certificate.
+type Policy struct {
+	mode   string
+	action string
+	suffix string
+	re     *regexp.Regexp
+
+	// allow is the hostnames (or hostname patterns) allowed for each certificate name.
+	allow map[string][]string
+}
+
+// NewPolicy returns the Policy configured in the `identity` section.
+func NewPolicy(cfg config.Conf) (*Policy, error) {
+	p := &Policy{
+		mode:   strings.ToLower(cfg.GetString("identity.mode")),
+		action: strings.ToLower(cfg.GetString("identity.action")),
+		suffix: strings.ToLower(cfg.GetString("identity.suffix")),
+	}
+
+	switch p.action {
+	case ActionReject, ActionRewrite:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, p.action)
+	}
+
+	switch p.mode {
+	case ModeExact:
+	case ModeSuffix:
+		if p.suffix == "" {
+			return nil, fmt.Errorf("%w: identity.suffix", ErrMissingOption)
+		}
+	case ModeRegex:
+		if cfg.GetString("identity.regex") == "" {
+			return nil, fmt.Errorf("%w: identity.regex", ErrMissingOption)
+		}
+
+		re, err := regexp.Compile("(?i)" + cfg.GetString("identity.regex"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse identity.regex: %w", err)
+		}
+
+		p.re = re
+	case ModeAllowList:
+		if cfg.GetString("identity.allowlist-file") == "" {
+			return nil, fmt.Errorf("%w: identity.allowlist-file", ErrMissingOption)
+		}
+
+		allow, err := LoadAllowList(cfg.GetString("identity.allowlist-file"))
+		if err != nil {
+			return nil, err
+		}
+
+		p.allow = allow
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, p.mode)
+	}
+
+	return p, nil
+}
+
+// LoadAllowList reads an allowlist file, each line is a certificate name followed by the hostnames
+// (or hostname patterns, eg. `web*`) it is allowed to use, separated by whitespace. Empty lines and
+// lines starting with `#` are ignored.
+func LoadAllowList(filename string) (map[string][]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open identity allowlist: %w", err)
+	}
+
+	defer f.Close()
+
+	out := map[string][]string{}
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) < 2 { //nolint:mnd // certificate name and a hostname.
+			return nil, fmt.Errorf("%w: line %d: no hostnames for %s", ErrInvalidAllowList, n, fields[0])
+		}
+
+		for _, pattern := range fields[1:] {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidAllowList, n, err)
+			}
+		}
+
+		out[fields[0]] = append(out[fields[0]], fields[1:]...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read identity allowlist: %w", err)
+	}
+
+	return out, nil
+}
+
+// Enforce returns the hostname to use for the identity and the decision, a rejected hostname is
+// returned unchanged. Hostnames that do not match are rejected when the identity has no hostname
+// to rewrite them to.
+func (p *Policy) Enforce(id Identity, hostname string) (string, Decision) {
+	if p.Allowed(id, hostname) {
+		return hostname, Allowed
+	}
+
+	if p.action == ActionRewrite {
+		if v, ok := p.Hostname(id); ok {
+			return v, Rewritten
+		}
+	}
+
+	return hostname, Rejected
+}
+
+// Allowed returns true if the hostname matches the identity.
+func (p *Policy) Allowed(id Identity, hostname string) bool {
+	hostname = strings.ToLower(hostname)
+	if hostname == "" {
+		return false
+	}
+
+	for _, name := range id.Names() {
+		name = strings.ToLower(name)
+
+		switch p.mode {
+		case ModeExact:
+			if hostname == name {
+				return true
+			}
+		case ModeSuffix:
+			if hostname == name || hostname+p.suffix == name || hostname == name+p.suffix {
+				return true
+			}
+		case ModeRegex:
+			if v, ok := p.regexHostname(name); ok && strings.EqualFold(v, hostname) {
+				return true
+			}
+		case ModeAllowList:
+			for _, pattern := range p.allow[name] {
+				if ok, _ := path.Match(pattern, hostname); ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Hostname returns the hostname of the identity that violating hostnames are rewritten to, it is
+// derived from the common name, or the first DNS name when the common name does not map to one.
+func (p *Policy) Hostname(id Identity) (string, bool) {
+	for _, name := range id.Names() {
+		name = strings.ToLower(name)
+
+		switch p.mode {
+		case ModeExact:
+			return name, true
+		case ModeSuffix:
+			return strings.TrimSuffix(name, p.suffix), true
+		case ModeRegex:
+			if v, ok := p.regexHostname(name); ok {
+				return v, true
+			}
+		case ModeAllowList:
+			for _, pattern := range p.allow[name] {
+				if !strings.ContainsAny(pattern, `*?[\`) {
+					return pattern, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// regexHostname returns the first submatch of the regex against the certificate name, or the whole
+// match when the regex has no groups.
+func (p *Policy) regexHostname(name string) (string, bool) {
+	m := p.re.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+
+	if len(m) > 1 {
+		return strings.ToLower(m[1]), m[1] != ""
+	}
+
+	return strings.ToLower(m[0]), m[0] != ""
+}
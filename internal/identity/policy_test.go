@@ -0,0 +1,143 @@
+package identity_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/internal/identity"
+	"github.com/spf13/viper"
+)
+
+func testPolicy(t *testing.T, opts map[string]string) *identity.Policy {
+	t.Helper()
+
+	vcfg := viper.New()
+	vcfg.Set("identity.mode", "exact")
+	vcfg.Set("identity.action", "reject")
+
+	for k, v := range opts {
+		vcfg.Set(k, v)
+	}
+
+	p, err := identity.NewPolicy(config.NewViperConfigFromViper(vcfg, "rsca-not-used"))
+	if err != nil {
+		t.Fatalf("identity.NewPolicy(): error, got '%s', want 'nil'", err)
+	}
+
+	return p
+}
+
+func TestPolicyEnforce(t *testing.T) {
+	t.Parallel()
+
+	allowFile := filepath.Join(t.TempDir(), "allowlist")
+	if err := os.WriteFile(allowFile, []byte(
+		"# certificate hostnames\nweb01.example.com web01 web01-*\n\nshared.example.com db*\n",
+	), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(): error, got '%s', want 'nil'", err)
+	}
+
+	web01 := identity.Identity{CommonName: "web01.example.com", DNSNames: []string{"web01.example.com", "www.example.com"}}
+	shared := identity.Identity{CommonName: "shared.example.com"}
+
+	tests := []struct {
+		name         string
+		opts         map[string]string
+		id           identity.Identity
+		hostname     string
+		wantHostname string
+		wantDecision identity.Decision
+	}{
+		{"exact cn", nil, web01, "WEB01.example.com", "WEB01.example.com", identity.Allowed},
+		{"exact san", nil, web01, "www.example.com", "www.example.com", identity.Allowed},
+		{"exact mismatch", nil, web01, "web02.example.com", "web02.example.com", identity.Rejected},
+		{
+			"exact rewrite", map[string]string{"identity.action": "rewrite"},
+			web01, "web02.example.com", "web01.example.com", identity.Rewritten,
+		},
+		{
+			"suffix short", map[string]string{"identity.mode": "suffix", "identity.suffix": ".example.com"},
+			web01, "web01", "web01", identity.Allowed,
+		},
+		{
+			"suffix rewrite", map[string]string{
+				"identity.mode": "suffix", "identity.suffix": ".example.com", "identity.action": "rewrite",
+			},
+			web01, "web02", "web01", identity.Rewritten,
+		},
+		{
+			"regex", map[string]string{"identity.mode": "regex", "identity.regex": `^([^.]+)\.example\.com$`},
+			web01, "web01", "web01", identity.Allowed,
+		},
+		{
+			"regex mismatch", map[string]string{"identity.mode": "regex", "identity.regex": `^([^.]+)\.example\.com$`},
+			web01, "web01.example.com", "web01.example.com", identity.Rejected,
+		},
+		{
+			"allowlist pattern", map[string]string{"identity.mode": "allowlist", "identity.allowlist-file": allowFile},
+			web01, "web01-blue", "web01-blue", identity.Allowed,
+		},
+		{
+			"allowlist rewrite", map[string]string{
+				"identity.mode": "allowlist", "identity.allowlist-file": allowFile, "identity.action": "rewrite",
+			},
+			web01, "db01", "web01", identity.Rewritten,
+		},
+		{
+			"allowlist no rewrite hostname", map[string]string{
+				"identity.mode": "allowlist", "identity.allowlist-file": allowFile, "identity.action": "rewrite",
+			},
+			shared, "web01", "web01", identity.Rejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hostname, decision := testPolicy(t, tt.opts).Enforce(tt.id, tt.hostname)
+			if hostname != tt.wantHostname || decision != tt.wantDecision {
+				t.Errorf("Policy.Enforce(): got '%s' '%s', want '%s' '%s'",
+					hostname, decision, tt.wantHostname, tt.wantDecision,
+				)
+			}
+		})
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts map[string]string
+		want error
+	}{
+		{"unknown mode", map[string]string{"identity.mode": "cn"}, identity.ErrUnknownMode},
+		{"unknown action", map[string]string{"identity.action": "drop"}, identity.ErrUnknownAction},
+		{"missing suffix", map[string]string{"identity.mode": "suffix"}, identity.ErrMissingOption},
+		{"missing regex", map[string]string{"identity.mode": "regex"}, identity.ErrMissingOption},
+		{"missing allowlist", map[string]string{"identity.mode": "allowlist"}, identity.ErrMissingOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vcfg := viper.New()
+			vcfg.Set("identity.mode", "exact")
+			vcfg.Set("identity.action", "reject")
+
+			for k, v := range tt.opts {
+				vcfg.Set(k, v)
+			}
+
+			if _, err := identity.NewPolicy(config.NewViperConfigFromViper(vcfg, "rsca-not-used")); !errors.Is(err, tt.want) {
+				t.Errorf("identity.NewPolicy(): error, got '%v', want '%v'", err, tt.want)
+			}
+		})
+	}
+}
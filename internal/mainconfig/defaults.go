@@ -3,59 +3,140 @@ package mainconfig
 import "github.com/spf13/viper"
 
 // ConfigInit is the common config initialisation for the commands.
-//
-//nolint:mnd // defaults are magic.
 func ConfigInit() {
-	viper.SetConfigName("rsca")
-	viper.SetConfigType("toml")
-	viper.AddConfigPath("./artifacts")
-	viper.AddConfigPath("./testdata")
-	viper.AddConfigPath("$HOME/.rsca")
-	viper.AddConfigPath("$HOME/.config")
-	viper.AddConfigPath("/run/secrets")
-	viper.AddConfigPath("/etc/rsca")
-	viper.AddConfigPath("/usr/local/etc")
-	viper.AddConfigPath("/usr/local/rsca/etc")
-	viper.AddConfigPath("/opt/homebrew/etc")
-	viper.AddConfigPath("/etc/nsca")
-	viper.AddConfigPath("/etc/nagios")
-	viper.AddConfigPath(".")
-
-	viper.SetDefault("general.jitter", "10s")
-	viper.SetDefault("general.retry", true)
-	viper.SetDefault("general.max-retries", 3)
-	viper.SetDefault("general.check-tick", "9s")
-	viper.SetDefault("general.tags", []string{})
-	viper.SetDefault("general.registration-interval", "180s")
-
-	viper.SetDefault("default.period", "120s")
-	viper.SetDefault("default.timeout", "3s")
-	viper.SetDefault("default.name-format", "uppercase")
-
-	viper.SetDefault("nagios.command-file", "/tmp/nagios.cmd")
-
-	viper.SetDefault("admin.server", "127.0.0.1:15888")
-	viper.SetDefault("admin.cert-type", "Cert")
-
-	viper.SetDefault("client.server", "127.0.0.1:15888")
-	viper.SetDefault("client.cert-type", "Client")
-
-	viper.SetDefault("server.listen", "0.0.0.0:15888")
-	viper.SetDefault("server.tick", "15s")
-	viper.SetDefault("server.cert-type", "Server")
-	viper.SetDefault("server.state-store", "/tmp/rsca-state.db")
-	viper.SetDefault("server.state-timeout", "120s")
-	viper.SetDefault("server.state-tick", "60s")
-
-	viper.SetDefault("watchdog.enabled", false)
-	viper.SetDefault("watchdog.tick", "30s")
-
-	viper.SetDefault("metrics.enabled", true)
-	viper.SetDefault("metrics.listen", "localhost:2112")
-	viper.SetDefault("metrics.timeout.read", "1h")
-	viper.SetDefault("metrics.timeout.read-header", "10s")
-	viper.SetDefault("metrics.timeout.write", "1m")
-	viper.SetDefault("metrics.timeout.idle", "10s")
+	Defaults(viper.GetViper())
 
 	_ = viper.ReadInConfig()
 }
+
+// Defaults sets the config file search paths and the default settings on vcfg.
+//
+//nolint:mnd // defaults are magic.
+func Defaults(vcfg *viper.Viper) {
+	vcfg.SetConfigName("rsca")
+	vcfg.SetConfigType("toml")
+	vcfg.AddConfigPath("./artifacts")
+	vcfg.AddConfigPath("./testdata")
+	vcfg.AddConfigPath("$HOME/.rsca")
+	vcfg.AddConfigPath("$HOME/.config")
+	vcfg.AddConfigPath("/run/secrets")
+	vcfg.AddConfigPath("/etc/rsca")
+	vcfg.AddConfigPath("/usr/local/etc")
+	vcfg.AddConfigPath("/usr/local/rsca/etc")
+	vcfg.AddConfigPath("/opt/homebrew/etc")
+	vcfg.AddConfigPath("/etc/nsca")
+	vcfg.AddConfigPath("/etc/nagios")
+	vcfg.AddConfigPath(".")
+
+	vcfg.SetDefault("general.jitter", "10s")
+	vcfg.SetDefault("general.retry", true)
+	vcfg.SetDefault("general.max-retries", 3)
+	vcfg.SetDefault("general.retry-timeout", "30s")
+	vcfg.SetDefault("general.check-tick", "9s")
+	vcfg.SetDefault("general.max-concurrent-checks", 4)
+	vcfg.SetDefault("general.tags", []string{})
+	vcfg.SetDefault("general.registration-interval", "180s")
+
+	vcfg.SetDefault("default.period", "120s")
+	vcfg.SetDefault("default.timeout", "3s")
+	vcfg.SetDefault("default.name-format", "uppercase")
+	vcfg.SetDefault("default.max-retries", 0)
+	vcfg.SetDefault("default.retry-interval", "30s")
+
+	vcfg.SetDefault("nagios.result-mode", "command")
+	vcfg.SetDefault("nagios.command-file", "/tmp/nagios.cmd")
+	vcfg.SetDefault("nagios.checkresult-path", "/var/spool/nagios/checkresults")
+	vcfg.SetDefault("nagios.queue-size", 10000)
+	vcfg.SetDefault("nagios.batch-size", 100)
+	vcfg.SetDefault("nagios.open-timeout", "5s")
+	vcfg.SetDefault("nagios.write-timeout", "5s")
+	vcfg.SetDefault("nagios.reconnect-interval", "1s")
+
+	vcfg.SetDefault("admin.server", "127.0.0.1:15888")
+	vcfg.SetDefault("admin.cert-type", "Cert")
+
+	vcfg.SetDefault("client.server", "127.0.0.1:15888")
+	vcfg.SetDefault("client.cert-type", "Client")
+	vcfg.SetDefault("client.failback", false)
+	vcfg.SetDefault("client.failback-interval", "5m")
+	vcfg.SetDefault("client.reconnect.min-backoff", "1s")
+	vcfg.SetDefault("client.reconnect.max-backoff", "60s")
+	vcfg.SetDefault("client.spool.enabled", false)
+	vcfg.SetDefault("client.spool.path", "/var/spool/rsca")
+	vcfg.SetDefault("client.spool.max-size", 64*1024*1024)
+	vcfg.SetDefault("client.spool.max-age", "24h")
+	vcfg.SetDefault("client.submit.enabled", false)
+	vcfg.SetDefault("client.submit.socket", "/run/rsca/submit.sock")
+	vcfg.SetDefault("client.submit.allow-hosts", []string{})
+	vcfg.SetDefault("client.submit.timeout", "10s")
+
+	vcfg.SetDefault("server.listen", "0.0.0.0:15888")
+	vcfg.SetDefault("server.tick", "15s")
+	vcfg.SetDefault("server.cert-type", "Server")
+	vcfg.SetDefault("server.state-store", "/tmp/rsca-state.db")
+	vcfg.SetDefault("server.state-timeout", "120s")
+	vcfg.SetDefault("server.state-tick", "60s")
+	vcfg.SetDefault("server.admin-listen", "")
+
+	vcfg.SetDefault("authz.enabled", false)
+	vcfg.SetDefault("authz.read.ou", []string{})
+	vcfg.SetDefault("authz.read.names", []string{})
+	vcfg.SetDefault("authz.read.fingerprints", []string{})
+	vcfg.SetDefault("authz.write.ou", []string{})
+	vcfg.SetDefault("authz.write.names", []string{})
+	vcfg.SetDefault("authz.write.fingerprints", []string{})
+
+	vcfg.SetDefault("audit.enabled", false)
+	vcfg.SetDefault("audit.path", "/var/log/rsca/audit.jsonl")
+	vcfg.SetDefault("audit.max-size-mb", 10)
+	vcfg.SetDefault("audit.max-backups", 5)
+
+	vcfg.SetDefault("host-status.enabled", false)
+	vcfg.SetDefault("host-status.grace-period", "1m")
+	vcfg.SetDefault("host-status.state", "down")
+	vcfg.SetDefault("host-status.tick", "5s")
+
+	vcfg.SetDefault("freshness.enabled", false)
+	vcfg.SetDefault("freshness.periods", 3)
+	vcfg.SetDefault("freshness.status", "unknown")
+	vcfg.SetDefault("freshness.tick", "30s")
+
+	vcfg.SetDefault("identity.enabled", false)
+	vcfg.SetDefault("identity.mode", "exact")
+	vcfg.SetDefault("identity.action", "reject")
+	vcfg.SetDefault("identity.suffix", "")
+	vcfg.SetDefault("identity.regex", "")
+	vcfg.SetDefault("identity.allowlist-file", "")
+
+	vcfg.SetDefault("history.enabled", true)
+	vcfg.SetDefault("history.max-count", 100)
+	vcfg.SetDefault("history.max-age", "168h")
+	vcfg.SetDefault("history.prune-interval", "1h")
+
+	vcfg.SetDefault("nsca.enabled", false)
+	vcfg.SetDefault("nsca.listen", "0.0.0.0:5667")
+	vcfg.SetDefault("nsca.encryption", "none")
+	vcfg.SetDefault("nsca.password", "")
+	vcfg.SetDefault("nsca.allow", []string{"127.0.0.1", "::1"})
+	vcfg.SetDefault("nsca.max-packet-age", "30s")
+	vcfg.SetDefault("nsca.timeout", "10s")
+
+	vcfg.SetDefault("nrpe.enabled", false)
+	vcfg.SetDefault("nrpe.listen", "0.0.0.0:5666")
+	vcfg.SetDefault("nrpe.tls", true)
+	vcfg.SetDefault("nrpe.cert-file", "")
+	vcfg.SetDefault("nrpe.key-file", "")
+	vcfg.SetDefault("nrpe.ca-file", "")
+	vcfg.SetDefault("nrpe.allow", []string{"127.0.0.1", "::1"})
+	vcfg.SetDefault("nrpe.timeout", "60s")
+
+	vcfg.SetDefault("watchdog.enabled", false)
+	vcfg.SetDefault("watchdog.tick", "30s")
+
+	vcfg.SetDefault("metrics.enabled", true)
+	vcfg.SetDefault("metrics.listen", "localhost:2112")
+	vcfg.SetDefault("metrics.timeout.read", "1h")
+	vcfg.SetDefault("metrics.timeout.read-header", "10s")
+	vcfg.SetDefault("metrics.timeout.write", "1m")
+	vcfg.SetDefault("metrics.timeout.idle", "10s")
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+)
+
+type AuditEntry struct {
+	Timestamp     time.Time     `json:"timestamp,omitempty"`
+	Method        string        `json:"method,omitempty"`
+	Identity      []string      `json:"identity,omitempty"`
+	Fingerprint   string        `json:"fingerprint,omitempty"`
+	PeerAddress   string        `json:"peer_address,omitempty"`
+	Targets       []string      `json:"targets,omitempty"`
+	AffectedHosts []string      `json:"affected_hosts,omitempty"`
+	Outcome       string        `json:"outcome,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	Duration      time.Duration `json:"duration,omitempty"`
+}
+
+func AuditEntryFromAPI(in *api.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		Timestamp:     in.GetTimestamp().AsTime(),
+		Method:        in.GetMethod(),
+		Identity:      in.GetIdentity(),
+		Fingerprint:   in.GetFingerprint(),
+		PeerAddress:   in.GetPeerAddress(),
+		Targets:       in.GetTargets(),
+		AffectedHosts: in.GetAffectedHosts(),
+		Outcome:       in.GetOutcome(),
+		Error:         in.GetError(),
+		Duration:      in.GetDuration().AsDuration(),
+	}
+}
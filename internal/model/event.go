@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+)
+
+type Event struct {
+	Type      string    `json:"type,omitempty"`
+	Hostname  string    `json:"hostname,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Check     string    `json:"check,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Output    string    `json:"output,omitempty"`
+	Result    *Result   `json:"result,omitempty"`
+	Member    *Member   `json:"member,omitempty"`
+	Dropped   uint64    `json:"dropped,omitempty"`
+}
+
+func EventFromAPI(in *api.WatchEvent) *Event {
+	o := &Event{
+		Type:      in.GetType().String(),
+		Hostname:  in.GetHostname(),
+		Timestamp: in.GetTimestamp().AsTime(),
+		Dropped:   in.GetDropped(),
+	}
+
+	if in.HasEvent() {
+		o.Result = ResultFromAPI(in.GetEvent())
+		o.Check = o.Result.Check
+		o.Status = o.Result.Status
+		o.Output = o.Result.Output
+	}
+
+	if in.HasMember() {
+		o.Member = MemberFromAPI(in.GetMember())
+	}
+
+	return o
+}
@@ -22,6 +22,7 @@ type Member struct {
 	SystemStart  time.Time     `json:"system_start,omitempty"`
 	ProcessStart time.Time     `json:"process_start,omitempty"`
 	Active       bool          `json:"active,omitempty"`
+	Server       string        `json:"server,omitempty"`
 	LastSeenAgo  string        `json:"lastseenago,omitempty"`
 	Latency      string        `json:"latency,omitempty"`
 }
@@ -42,6 +43,7 @@ func MemberFromAPI(in *api.Member) *Member {
 		SystemStart:  in.GetSystemStart().AsTime(),
 		ProcessStart: in.GetProcessStart().AsTime(),
 		Active:       in.GetActive(),
+		Server:       in.GetServer(),
 		LastSeenAgo:  in.GetLastSeenAgo(),
 		Latency:      in.GetLatency(),
 		InfoStat:     InfoStatFromAPI(in.GetInfoStat()),
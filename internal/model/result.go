@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+)
+
+type Result struct {
+	ID         string        `json:"id,omitempty"`
+	Hostname   string        `json:"hostname,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	Check      string        `json:"check,omitempty"`
+	Status     string        `json:"status,omitempty"`
+	Output     string        `json:"output,omitempty"`
+	LongOutput string        `json:"long_output,omitempty"`
+	Perfdata   string        `json:"perfdata,omitempty"`
+	Timestamp  time.Time     `json:"timestamp,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	Retries    int32         `json:"retries,omitempty"`
+	MaxRetries int32         `json:"max_retries,omitempty"`
+	Soft       bool          `json:"soft,omitempty"`
+}
+
+func ResultFromAPI(in *api.EventMessage) *Result {
+	return &Result{
+		ID:         in.GetId(),
+		Hostname:   in.GetHostname(),
+		Type:       in.GetType().String(),
+		Check:      in.GetCheck(),
+		Status:     in.GetStatus().String(),
+		Output:     in.GetOutput(),
+		LongOutput: in.GetLongOutput(),
+		Perfdata:   in.GetPerfdata(),
+		Timestamp:  in.GetRequestTimestamp().AsTime(),
+		Duration:   in.GetDuration().AsDuration(),
+		Retries:    in.GetRetries(),
+		MaxRetries: in.GetMaxRetries(),
+		Soft:       in.IsSoftState(),
+	}
+}
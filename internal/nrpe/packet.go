@@ -0,0 +1,173 @@
+package nrpe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	// PacketVersion2 is the fixed size packet format used by NRPE 2.x.
+	PacketVersion2 = 2
+
+	// PacketVersion3 is the variable size packet format introduced in NRPE 3.0.
+	PacketVersion3 = 3
+
+	// PacketVersion4 is the variable size packet format used by NRPE 3.2 and later.
+	PacketVersion4 = 4
+
+	// QueryPacket is the packet type of a query from check_nrpe.
+	QueryPacket = 1
+
+	// ResponsePacket is the packet type of a response to check_nrpe.
+	ResponsePacket = 2
+
+	// v2BufferSize is the size of the buffer in a version 2 packet.
+	v2BufferSize = 1024
+
+	// v2PacketSize is the size of a version 2 packet (including alignment padding).
+	v2PacketSize = 10 + v2BufferSize + 2
+
+	// v3HeaderSize is the size of the fields before the buffer in a version 3 or 4 packet.
+	v3HeaderSize = 16
+
+	// v3SizeOffset is the extra padding NRPE 3.0 and 3.1 include in version 3 packets.
+	v3SizeOffset = 3
+
+	// MaxBufferSize is the largest buffer accepted in a version 3 or 4 packet.
+	MaxBufferSize = 65536
+)
+
+const (
+	offsetVersion      = 0
+	offsetType         = 2
+	offsetCRC          = 4
+	offsetResultCode   = 8
+	offsetV2Buffer     = 10
+	offsetBufferLength = 12
+)
+
+var (
+	// ErrInvalidCRC is returned when the CRC32 of a packet does not match.
+	ErrInvalidCRC = errors.New("invalid packet crc32")
+
+	// ErrInvalidVersion is returned when the packet version is not supported.
+	ErrInvalidVersion = errors.New("invalid packet version")
+
+	// ErrInvalidBufferSize is returned when a variable size packet has an invalid buffer length.
+	ErrInvalidBufferSize = errors.New("invalid packet buffer size")
+)
+
+// Packet is a decoded NRPE packet.
+type Packet struct {
+	Version    int
+	Type       int
+	ResultCode int
+	Buffer     string
+}
+
+// ReadPacket reads and validates a packet.
+func ReadPacket(r io.Reader) (*Packet, error) {
+	header := make([]byte, v3HeaderSize)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err //nolint:wrapcheck // io.EOF is checked by the caller.
+	}
+
+	version := int(binary.BigEndian.Uint16(header[offsetVersion:]))
+
+	var (
+		size     int
+		bufStart int
+		bufEnd   int
+	)
+
+	switch version {
+	case PacketVersion2:
+		size, bufStart, bufEnd = v2PacketSize, offsetV2Buffer, offsetV2Buffer+v2BufferSize
+	case PacketVersion3, PacketVersion4:
+		length := int(int32(binary.BigEndian.Uint32(header[offsetBufferLength:]))) //nolint:gosec // signed field.
+		if length < 0 || length > MaxBufferSize {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidBufferSize, length)
+		}
+
+		size, bufStart, bufEnd = variablePacketSize(version, length), v3HeaderSize, v3HeaderSize+length
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+
+	buf := make([]byte, size)
+	copy(buf, header)
+
+	if _, err := io.ReadFull(r, buf[v3HeaderSize:]); err != nil {
+		return nil, fmt.Errorf("unable to read packet: %w", err)
+	}
+
+	if binary.BigEndian.Uint32(buf[offsetCRC:]) != packetCRC(buf) {
+		return nil, ErrInvalidCRC
+	}
+
+	return &Packet{
+		Version:    version,
+		Type:       int(binary.BigEndian.Uint16(buf[offsetType:])),
+		ResultCode: int(int16(binary.BigEndian.Uint16(buf[offsetResultCode:]))), //nolint:gosec // signed field.
+		Buffer:     cString(buf[bufStart:bufEnd]),
+	}, nil
+}
+
+// Encode encodes the packet in its version's format, the buffer is truncated to fit.
+func (p *Packet) Encode() []byte {
+	var buf []byte
+
+	switch p.Version {
+	case PacketVersion3, PacketVersion4:
+		data := []byte(p.Buffer)
+		if len(data) > MaxBufferSize-1 {
+			data = data[:MaxBufferSize-1]
+		}
+
+		length := len(data) + 1
+		buf = make([]byte, variablePacketSize(p.Version, length))
+		binary.BigEndian.PutUint32(buf[offsetBufferLength:], uint32(length)) //nolint:gosec // bounded above.
+		copy(buf[v3HeaderSize:], data)
+	default:
+		buf = make([]byte, v2PacketSize)
+		copy(buf[offsetV2Buffer:offsetV2Buffer+v2BufferSize-1], p.Buffer)
+	}
+
+	binary.BigEndian.PutUint16(buf[offsetVersion:], uint16(p.Version))       //nolint:gosec // small value.
+	binary.BigEndian.PutUint16(buf[offsetType:], uint16(p.Type))             //nolint:gosec // small value.
+	binary.BigEndian.PutUint16(buf[offsetResultCode:], uint16(p.ResultCode)) //nolint:gosec // signed field.
+	binary.BigEndian.PutUint32(buf[offsetCRC:], packetCRC(buf))
+
+	return buf
+}
+
+// variablePacketSize returns the size of a version 3 or 4 packet with the supplied buffer length.
+func variablePacketSize(version, length int) int {
+	if version == PacketVersion3 {
+		return v3HeaderSize + v3SizeOffset + length
+	}
+
+	return v3HeaderSize + length
+}
+
+// packetCRC calculates the CRC32 of a packet with the CRC field set to zero.
+func packetCRC(buf []byte) uint32 {
+	tmp := bytes.Clone(buf)
+	binary.BigEndian.PutUint32(tmp[offsetCRC:], 0)
+
+	return crc32.ChecksumIEEE(tmp)
+}
+
+// cString returns the string up to the first NUL byte.
+func cString(buf []byte) string {
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+
+	return string(buf)
+}
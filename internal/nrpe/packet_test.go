@@ -0,0 +1,78 @@
+package nrpe_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/internal/nrpe"
+)
+
+func TestPacketRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version int
+		size    int
+	}{
+		{nrpe.PacketVersion2, 1036},
+		{nrpe.PacketVersion3, 16 + 3 + 18},
+		{nrpe.PacketVersion4, 16 + 18},
+	}
+
+	for _, tt := range tests {
+		in := &nrpe.Packet{
+			Version:    tt.version,
+			Type:       nrpe.ResponsePacket,
+			ResultCode: 2,
+			Buffer:     "DISK CRITICAL|a=1",
+		}
+
+		buf := in.Encode()
+		if len(buf) != tt.size {
+			t.Errorf("Packet.Encode(v%d): length got '%d', want '%d'", tt.version, len(buf), tt.size)
+		}
+
+		out, err := nrpe.ReadPacket(bytes.NewReader(buf))
+		if err != nil {
+			t.Fatalf("nrpe.ReadPacket(v%d): error, got '%s', want 'nil'", tt.version, err)
+		}
+
+		if diff := cmp.Diff(in, out); diff != "" {
+			t.Errorf("nrpe.ReadPacket(v%d): -want +got:\n%s", tt.version, diff)
+		}
+	}
+}
+
+func TestPacketTruncatesVersion2Buffer(t *testing.T) {
+	t.Parallel()
+
+	buf := (&nrpe.Packet{Version: nrpe.PacketVersion2, Buffer: string(bytes.Repeat([]byte("x"), 2000))}).Encode()
+
+	out, err := nrpe.ReadPacket(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatalf("nrpe.ReadPacket(): error, got '%s', want 'nil'", err)
+	}
+
+	if len(out.Buffer) != 1023 {
+		t.Errorf("nrpe.ReadPacket(): buffer length got '%d', want '%d'", len(out.Buffer), 1023)
+	}
+}
+
+func TestPacketInvalid(t *testing.T) {
+	t.Parallel()
+
+	buf := (&nrpe.Packet{Version: nrpe.PacketVersion4, Type: nrpe.QueryPacket, Buffer: "check_load"}).Encode()
+	buf[len(buf)-2] ^= 0xff
+
+	if _, err := nrpe.ReadPacket(bytes.NewReader(buf)); !errors.Is(err, nrpe.ErrInvalidCRC) {
+		t.Errorf("nrpe.ReadPacket(): error got '%v', want '%s'", err, nrpe.ErrInvalidCRC)
+	}
+
+	buf[1] = 9
+
+	if _, err := nrpe.ReadPacket(bytes.NewReader(buf)); !errors.Is(err, nrpe.ErrInvalidVersion) {
+		t.Errorf("nrpe.ReadPacket(): error got '%v', want '%s'", err, nrpe.ErrInvalidVersion)
+	}
+}
@@ -0,0 +1,3 @@
+// Package nrpe contains a listener that answers `check_nrpe` queries by running the configured
+// checks, it allows hosts to be migrated to rsca before the Nagios configuration is rewritten.
+package nrpe
@@ -0,0 +1,261 @@
+package nrpe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// versionCommand is the query check_nrpe sends when it is run without a command.
+const versionCommand = "_NRPE_CHECK"
+
+var (
+	// ErrInvalidCA is returned when the `nrpe.ca-file` does not contain any certificates.
+	ErrInvalidCA = errors.New("no certificates found in ca file")
+
+	// ErrMissingCertificate is returned when `nrpe.tls` is enabled without a certificate and key.
+	ErrMissingCertificate = errors.New("nrpe.tls is enabled but nrpe.cert-file or nrpe.key-file is not set")
+)
+
+// Lookup returns a copy of the configured check with the supplied name.
+type Lookup func(name string) (*checks.Info, bool)
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "connections_total",
+		Namespace: "rsca",
+		Subsystem: "nrpe",
+		Help:      "number of nrpe connections by result",
+	}, []string{"result"})
+	metricRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "requests_total",
+		Namespace: "rsca",
+		Subsystem: "nrpe",
+		Help:      "number of nrpe queries by result",
+	}, []string{"result"})
+)
+
+// Server accepts queries from check_nrpe and answers them by running the configured checks.
+type Server struct {
+	Logger    *slog.Logger
+	lookup    Lookup
+	version   string
+	listen    string
+	allow     helpers.AllowList
+	tlsConfig *tls.Config
+	timeout   time.Duration
+}
+
+// NewServer returns a Server configured from the `nrpe` config section.
+func NewServer(cfg config.Conf, logger *slog.Logger, lookup Lookup, version string) (*Server, error) {
+	allow, err := helpers.ParseAllowList(cfg.GetStringSlice("nrpe.allow"))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		Logger:  logger,
+		lookup:  lookup,
+		version: version,
+		listen:  cfg.GetString("nrpe.listen"),
+		allow:   allow,
+		timeout: cfg.GetDuration("nrpe.timeout"),
+	}
+
+	if cfg.GetBool("nrpe.tls") {
+		if s.tlsConfig, err = tlsConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// tlsConfig returns the TLS configuration for the listener, client certificates are required
+// when a `nrpe.ca-file` is configured.
+func tlsConfig(cfg config.Conf) (*tls.Config, error) {
+	if cfg.GetString("nrpe.cert-file") == "" || cfg.GetString("nrpe.key-file") == "" {
+		return nil, ErrMissingCertificate
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.GetString("nrpe.cert-file"), cfg.GetString("nrpe.key-file"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load nrpe certificate: %w", err)
+	}
+
+	tc := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if caFile := cfg.GetString("nrpe.ca-file"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read nrpe ca file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCA, caFile)
+		}
+
+		tc.ClientCAs = pool
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tc, nil
+}
+
+// Run is a routine that listens for NRPE connections until the context is cancelled.
+func (s *Server) Run(ctx context.Context) func() error {
+	return func() error {
+		lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.listen)
+		if err != nil {
+			return fmt.Errorf("unable to listen for nrpe connections: %w", err)
+		}
+
+		if s.tlsConfig != nil {
+			lis = tls.NewListener(lis, s.tlsConfig)
+		}
+
+		s.Logger.InfoContext(ctx, "nrpe server listening",
+			slog.String("bind", s.listen), slog.Bool("tls", s.tlsConfig != nil),
+		)
+
+		return s.Serve(ctx, lis)
+	}
+}
+
+// Serve accepts NRPE connections on the listener until the context is cancelled.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("unable to accept nrpe connection: %w", err)
+		}
+
+		if !s.allow.IsAllowed(conn.RemoteAddr()) {
+			metricConnections.WithLabelValues("rejected").Inc()
+			s.Logger.WarnContext(ctx, "nrpe connection rejected, source not in allow list",
+				slog.String("source", conn.RemoteAddr().String()),
+			)
+
+			_ = conn.Close()
+
+			continue
+		}
+
+		metricConnections.WithLabelValues("accepted").Inc()
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+// handleConn reads a single query, runs the check and writes the response, check_nrpe sends one
+// query per connection.
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	source := conn.RemoteAddr().String()
+
+	_ = conn.SetReadDeadline(time.Now().Add(s.timeout))
+
+	query, err := ReadPacket(conn)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			metricRequests.WithLabelValues("invalid").Inc()
+			s.Logger.WarnContext(ctx, "unable to read nrpe packet", slog.String("source", source), slogtool.ErrorAttr(err))
+		}
+
+		return
+	}
+
+	if query.Type != QueryPacket {
+		metricRequests.WithLabelValues("invalid").Inc()
+		s.Logger.WarnContext(ctx, "unexpected nrpe packet type",
+			slog.String("source", source), slog.Int("packet.type", query.Type),
+		)
+
+		return
+	}
+
+	resp := s.handleQuery(ctx, source, query)
+	resp.Version = query.Version
+	resp.Type = ResponsePacket
+
+	_ = conn.SetWriteDeadline(time.Now().Add(s.timeout))
+
+	if _, err = conn.Write(resp.Encode()); err != nil {
+		s.Logger.WarnContext(ctx, "unable to send nrpe response", slog.String("source", source), slogtool.ErrorAttr(err))
+	}
+}
+
+// handleQuery runs the check named in the query, any arguments (`command!arg1!arg2`) are ignored
+// as the check command is taken from the configuration.
+func (s *Server) handleQuery(ctx context.Context, source string, query *Packet) *Packet {
+	name, _, _ := strings.Cut(query.Buffer, "!")
+
+	if name == versionCommand {
+		metricRequests.WithLabelValues("version").Inc()
+
+		return &Packet{ResultCode: int(api.Status_OK), Buffer: "NRPE v4 (rsca " + s.version + ")"}
+	}
+
+	check, ok := s.lookup(name)
+	if !ok {
+		metricRequests.WithLabelValues("not_found").Inc()
+		s.Logger.WarnContext(ctx, "nrpe query for unknown check",
+			slog.String("source", source), slog.String("check.name", name),
+		)
+
+		return &Packet{
+			ResultCode: int(api.Status_UNKNOWN),
+			Buffer:     fmt.Sprintf("NRPE: Command '%s' not defined", name),
+		}
+	}
+
+	timeout := s.timeout
+	if check.Timeout > 0 {
+		timeout = check.Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	msg := check.Run(ctx, time.Now())
+
+	metricRequests.WithLabelValues("ok").Inc()
+	s.Logger.DebugContext(ctx, "nrpe query",
+		slog.String("source", source),
+		slog.String("check.name", check.Name),
+		slog.String("check.status", msg.GetStatus().String()),
+	)
+
+	return &Packet{ResultCode: int(msg.GetStatus()), Buffer: msg.PluginOutput()}
+}
@@ -0,0 +1,112 @@
+package nrpe_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/na4ma4/rsca/internal/nrpe"
+	"github.com/spf13/viper"
+)
+
+func TestServerRequiresCertificateForTLS(t *testing.T) {
+	t.Parallel()
+
+	vcfg := viper.New()
+	vcfg.Set("nrpe.tls", true)
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+
+	_, err := nrpe.NewServer(cfg, slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		func(string) (*checks.Info, bool) { return nil, false },
+		"test",
+	)
+	if !errors.Is(err, nrpe.ErrMissingCertificate) {
+		t.Errorf("nrpe.NewServer(): error, got '%v', want '%s'", err, nrpe.ErrMissingCertificate)
+	}
+}
+
+func TestServerRunsChecks(t *testing.T) {
+	t.Parallel()
+
+	vcfg := viper.New()
+	vcfg.Set("nrpe.allow", []string{"127.0.0.0/8"})
+	vcfg.Set("nrpe.timeout", "5s")
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+	checkList := checks.Checks{
+		{Name: "check_echo", Command: "echo 'WARNING - almost full|used=91%'"},
+	}
+
+	var buf bytes.Buffer
+
+	srv, err := nrpe.NewServer(cfg, slog.New(slog.NewJSONHandler(&buf, nil)),
+		func(name string) (*checks.Info, bool) {
+			return checkList.GetByName(name)
+		},
+		"test",
+	)
+	if err != nil {
+		t.Fatalf("nrpe.NewServer(): error, got '%s', want 'nil'", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): error, got '%s', want 'nil'", err)
+	}
+
+	go func() { _ = srv.Serve(ctx, lis) }()
+
+	tests := []struct {
+		version int
+		query   string
+		want    *nrpe.Packet
+	}{
+		{nrpe.PacketVersion2, "check_echo!ignored", &nrpe.Packet{
+			Version: nrpe.PacketVersion2, Type: nrpe.ResponsePacket, Buffer: "WARNING - almost full|used=91%",
+		}},
+		{nrpe.PacketVersion3, "CHECK_ECHO", &nrpe.Packet{
+			Version: nrpe.PacketVersion3, Type: nrpe.ResponsePacket, Buffer: "WARNING - almost full|used=91%",
+		}},
+		{nrpe.PacketVersion4, "check_missing", &nrpe.Packet{
+			Version: nrpe.PacketVersion4, Type: nrpe.ResponsePacket, ResultCode: 3,
+			Buffer: "NRPE: Command 'check_missing' not defined",
+		}},
+		{nrpe.PacketVersion2, "_NRPE_CHECK", &nrpe.Packet{
+			Version: nrpe.PacketVersion2, Type: nrpe.ResponsePacket, Buffer: "NRPE v4 (rsca test)",
+		}},
+	}
+
+	for _, tt := range tests {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", lis.Addr().String())
+		if err != nil {
+			t.Fatalf("net.Dial(): error, got '%s', want 'nil'", err)
+		}
+
+		query := &nrpe.Packet{Version: tt.version, Type: nrpe.QueryPacket, Buffer: tt.query}
+		if _, err = conn.Write(query.Encode()); err != nil {
+			t.Fatalf("writing query: error, got '%s', want 'nil'", err)
+		}
+
+		got, err := nrpe.ReadPacket(conn)
+		_ = conn.Close()
+
+		if err != nil {
+			t.Fatalf("nrpe.ReadPacket(%s): error, got '%s', want 'nil'", tt.query, err)
+		}
+
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("nrpe query %s: -want +got:\n%s", tt.query, diff)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package nsca
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"time"
+)
+
+const (
+	// PacketVersion is the data packet version sent by NSCA 2.x clients.
+	PacketVersion = 3
+
+	// PacketVersion2 is the data packet version sent by older clients, the packet layout is the same
+	// as PacketVersion.
+	PacketVersion2 = 2
+
+	// IVSize is the size of the initialisation vector sent to the client when it connects.
+	IVSize = 128
+
+	// InitPacketSize is the size of the initialisation packet (IV and timestamp).
+	InitPacketSize = IVSize + 4
+
+	// hostnameSize is the size of the hostname field.
+	hostnameSize = 64
+
+	// serviceSize is the size of the service description field.
+	serviceSize = 128
+
+	// ShortOutputSize is the plugin output size used by older NSCA releases (up to 2.7).
+	ShortOutputSize = 512
+
+	// LongOutputSize is the plugin output size used by newer NSCA releases.
+	LongOutputSize = 4096
+
+	// headerSize is the size of the fields before the plugin output (including alignment padding).
+	headerSize = 14 + hostnameSize + serviceSize
+
+	// ShortPacketSize is the size of a data packet with ShortOutputSize plugin output.
+	ShortPacketSize = headerSize + ShortOutputSize + 2
+
+	// LongPacketSize is the size of a data packet with LongOutputSize plugin output.
+	LongPacketSize = headerSize + LongOutputSize + 2
+)
+
+const (
+	offsetVersion    = 0
+	offsetCRC        = 4
+	offsetTimestamp  = 8
+	offsetReturnCode = 12
+	offsetHostname   = 14
+	offsetService    = offsetHostname + hostnameSize
+	offsetOutput     = offsetService + serviceSize
+)
+
+var (
+	// ErrInvalidPacketSize is returned when a packet is not one of the known sizes.
+	ErrInvalidPacketSize = errors.New("invalid packet size")
+
+	// ErrInvalidCRC is returned when the CRC32 of a packet does not match.
+	ErrInvalidCRC = errors.New("invalid packet crc32")
+
+	// ErrInvalidVersion is returned when the packet version is not supported.
+	ErrInvalidVersion = errors.New("invalid packet version")
+
+	// ErrUnknownEncryption is returned when the encryption method is not supported.
+	ErrUnknownEncryption = errors.New("unsupported encryption method")
+)
+
+// Encryption is the method used to encrypt data packets.
+type Encryption int
+
+const (
+	// EncryptionNone sends packets in clear text.
+	EncryptionNone Encryption = 0
+
+	// EncryptionXOR XORs packets with the IV and password.
+	EncryptionXOR Encryption = 1
+)
+
+// ParseEncryption returns the encryption method from the name or number used in nsca.cfg.
+func ParseEncryption(in string) (Encryption, error) {
+	switch strings.ToLower(strings.TrimSpace(in)) {
+	case "", "0", "none":
+		return EncryptionNone, nil
+	case "1", "xor":
+		return EncryptionXOR, nil
+	default:
+		return EncryptionNone, fmt.Errorf("%w: %s", ErrUnknownEncryption, in)
+	}
+}
+
+// Crypt encrypts or decrypts a packet in place, XOR is symmetrical so the same function does both.
+func (e Encryption) Crypt(buf, iv, password []byte) {
+	if e != EncryptionXOR {
+		return
+	}
+
+	for i := range buf {
+		buf[i] ^= iv[i%len(iv)]
+	}
+
+	if len(password) == 0 {
+		return
+	}
+
+	for i := range buf {
+		buf[i] ^= password[i%len(password)]
+	}
+}
+
+// Packet is a decoded NSCA data packet.
+type Packet struct {
+	// Version is the packet version, PacketVersion is used when encoding a packet without a version.
+	Version int
+
+	Timestamp  time.Time
+	ReturnCode int
+	Hostname   string
+	Service    string
+	Output     string
+}
+
+// InitPacket returns the initialisation packet sent to the client when it connects.
+func InitPacket(iv []byte, ts time.Time) []byte {
+	buf := make([]byte, InitPacketSize)
+	copy(buf, iv)
+	binary.BigEndian.PutUint32(buf[IVSize:], uint32(ts.Unix())) //nolint:gosec // NSCA timestamps are 32bit.
+
+	return buf
+}
+
+// ValidCRC returns true if the decrypted packet has a valid CRC32.
+func ValidCRC(buf []byte) bool {
+	if len(buf) < offsetOutput {
+		return false
+	}
+
+	return binary.BigEndian.Uint32(buf[offsetCRC:]) == packetCRC(buf)
+}
+
+// packetCRC calculates the CRC32 of a packet with the CRC field set to zero.
+func packetCRC(buf []byte) uint32 {
+	tmp := bytes.Clone(buf)
+	binary.BigEndian.PutUint32(tmp[offsetCRC:], 0)
+
+	return crc32.ChecksumIEEE(tmp)
+}
+
+// Decode decodes a decrypted data packet.
+func Decode(buf []byte) (*Packet, error) {
+	if len(buf) != ShortPacketSize && len(buf) != LongPacketSize {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPacketSize, len(buf))
+	}
+
+	if !ValidCRC(buf) {
+		return nil, ErrInvalidCRC
+	}
+
+	version := int(int16(binary.BigEndian.Uint16(buf[offsetVersion:]))) //nolint:gosec // signed field.
+	if version != PacketVersion && version != PacketVersion2 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, version)
+	}
+
+	return &Packet{
+		Version:    version,
+		Timestamp:  time.Unix(int64(binary.BigEndian.Uint32(buf[offsetTimestamp:])), 0),
+		ReturnCode: int(int16(binary.BigEndian.Uint16(buf[offsetReturnCode:]))), //nolint:gosec // signed field.
+		Hostname:   cString(buf[offsetHostname : offsetHostname+hostnameSize]),
+		Service:    cString(buf[offsetService : offsetService+serviceSize]),
+		Output:     cString(buf[offsetOutput : len(buf)-2]),
+	}, nil
+}
+
+// Encode encodes an unencrypted data packet with the supplied plugin output size.
+func (p *Packet) Encode(outputSize int) []byte {
+	buf := make([]byte, headerSize+outputSize+2)
+
+	version := p.Version
+	if version == 0 {
+		version = PacketVersion
+	}
+
+	binary.BigEndian.PutUint16(buf[offsetVersion:], uint16(version))              //nolint:gosec // signed field.
+	binary.BigEndian.PutUint32(buf[offsetTimestamp:], uint32(p.Timestamp.Unix())) //nolint:gosec // 32bit timestamp.
+	binary.BigEndian.PutUint16(buf[offsetReturnCode:], uint16(p.ReturnCode))      //nolint:gosec // signed field.
+	copy(buf[offsetHostname:offsetHostname+hostnameSize-1], p.Hostname)
+	copy(buf[offsetService:offsetService+serviceSize-1], p.Service)
+	copy(buf[offsetOutput:offsetOutput+outputSize-1], p.Output)
+	binary.BigEndian.PutUint32(buf[offsetCRC:], packetCRC(buf))
+
+	return buf
+}
+
+// cString returns the string up to the first NUL byte.
+func cString(buf []byte) string {
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+
+	return string(buf)
+}
@@ -0,0 +1,112 @@
+package nsca_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/internal/nsca"
+)
+
+func testPacket() *nsca.Packet {
+	return &nsca.Packet{
+		Version:    nsca.PacketVersion,
+		Timestamp:  time.Unix(1700000000, 0),
+		ReturnCode: 2,
+		Hostname:   "legacy.example.com",
+		Service:    "BACKUP",
+		Output:     "backup failed|size=0B",
+	}
+}
+
+func TestPacketRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{nsca.ShortOutputSize, nsca.LongOutputSize} {
+		buf := testPacket().Encode(size)
+
+		if size == nsca.ShortOutputSize && len(buf) != nsca.ShortPacketSize {
+			t.Errorf("Packet.Encode(): length got '%d', want '%d'", len(buf), nsca.ShortPacketSize)
+		}
+
+		if size == nsca.LongOutputSize && len(buf) != nsca.LongPacketSize {
+			t.Errorf("Packet.Encode(): length got '%d', want '%d'", len(buf), nsca.LongPacketSize)
+		}
+
+		pkt, err := nsca.Decode(buf)
+		if err != nil {
+			t.Fatalf("nsca.Decode(): error, got '%s', want 'nil'", err)
+		}
+
+		if diff := cmp.Diff(pkt, testPacket()); diff != "" {
+			t.Errorf("nsca.Decode(): packet -got +want:\n%s", diff)
+		}
+	}
+}
+
+func TestPacketVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version int
+		wantErr error
+	}{
+		{nsca.PacketVersion, nil},
+		{nsca.PacketVersion2, nil},
+		{1, nsca.ErrInvalidVersion},
+	}
+
+	for _, tt := range tests {
+		want := testPacket()
+		want.Version = tt.version
+
+		pkt, err := nsca.Decode(want.Encode(nsca.ShortOutputSize))
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("nsca.Decode(version %d): error, got '%v', want '%v'", tt.version, err, tt.wantErr)
+
+			continue
+		}
+
+		if diff := cmp.Diff(pkt, want); err == nil && diff != "" {
+			t.Errorf("nsca.Decode(version %d): packet -got +want:\n%s", tt.version, diff)
+		}
+	}
+}
+
+func TestPacketInvalid(t *testing.T) {
+	t.Parallel()
+
+	buf := testPacket().Encode(nsca.ShortOutputSize)
+	buf[100] ^= 0xff
+
+	if _, err := nsca.Decode(buf); !errors.Is(err, nsca.ErrInvalidCRC) {
+		t.Errorf("nsca.Decode(): error, got '%v', want '%s'", err, nsca.ErrInvalidCRC)
+	}
+
+	if _, err := nsca.Decode(buf[:100]); !errors.Is(err, nsca.ErrInvalidPacketSize) {
+		t.Errorf("nsca.Decode(): error, got '%v', want '%s'", err, nsca.ErrInvalidPacketSize)
+	}
+}
+
+func TestEncryptionXOR(t *testing.T) {
+	t.Parallel()
+
+	iv := make([]byte, nsca.IVSize)
+	for i := range iv {
+		iv[i] = byte(i * 7)
+	}
+
+	buf := testPacket().Encode(nsca.ShortOutputSize)
+	nsca.EncryptionXOR.Crypt(buf, iv, []byte("secret"))
+
+	if nsca.ValidCRC(buf) {
+		t.Error("ValidCRC(): encrypted packet should not have a valid crc32")
+	}
+
+	nsca.EncryptionXOR.Crypt(buf, iv, []byte("secret"))
+
+	if _, err := nsca.Decode(buf); err != nil {
+		t.Errorf("nsca.Decode(): error after decrypting, got '%s', want 'nil'", err)
+	}
+}
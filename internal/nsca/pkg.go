@@ -0,0 +1,3 @@
+// Package nsca contains a listener that accepts check results from legacy NSCA 2.x clients
+// (eg. `send_nsca`) so they can be processed alongside results from rsca clients.
+package nsca
@@ -0,0 +1,271 @@
+package nsca
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/checks"
+	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrPacketExpired is returned when a packet timestamp is outside of `nsca.max-packet-age`.
+var ErrPacketExpired = errors.New("packet timestamp outside of max packet age")
+
+// Handler processes a check result decoded from a packet, source is the IP address of the remote
+// host (without the port, so it can be used as a metric label).
+type Handler func(ctx context.Context, source string, msg *api.EventMessage) error
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "connections_total",
+		Namespace: "rsca",
+		Subsystem: "nsca",
+		Help:      "number of nsca connections by result",
+	}, []string{"result"})
+	metricPackets = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "packets_total",
+		Namespace: "rsca",
+		Subsystem: "nsca",
+		Help:      "number of nsca data packets received by result",
+	}, []string{"result"})
+)
+
+// Server accepts connections from NSCA clients and passes the decoded check results to a Handler.
+type Server struct {
+	Logger     *slog.Logger
+	handler    Handler
+	listen     string
+	allow      helpers.AllowList
+	encryption Encryption
+	password   []byte
+	maxAge     time.Duration
+	timeout    time.Duration
+}
+
+// NewServer returns a Server configured from the `nsca` config section.
+func NewServer(cfg config.Conf, logger *slog.Logger, handler Handler) (*Server, error) {
+	encryption, err := ParseEncryption(cfg.GetString("nsca.encryption"))
+	if err != nil {
+		return nil, err
+	}
+
+	allow, err := helpers.ParseAllowList(cfg.GetStringSlice("nsca.allow"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Logger:     logger,
+		handler:    handler,
+		listen:     cfg.GetString("nsca.listen"),
+		allow:      allow,
+		encryption: encryption,
+		password:   []byte(cfg.GetString("nsca.password")),
+		maxAge:     cfg.GetDuration("nsca.max-packet-age"),
+		timeout:    cfg.GetDuration("nsca.timeout"),
+	}, nil
+}
+
+// Run is a routine that listens for NSCA connections until the context is cancelled.
+func (s *Server) Run(ctx context.Context) func() error {
+	return func() error {
+		lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.listen)
+		if err != nil {
+			return fmt.Errorf("unable to listen for nsca connections: %w", err)
+		}
+
+		s.Logger.InfoContext(ctx, "nsca server listening", slog.String("bind", s.listen))
+
+		return s.Serve(ctx, lis)
+	}
+}
+
+// Serve accepts NSCA connections on the listener until the context is cancelled.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("unable to accept nsca connection: %w", err)
+		}
+
+		if !s.allow.IsAllowed(conn.RemoteAddr()) {
+			metricConnections.WithLabelValues("rejected").Inc()
+			s.Logger.WarnContext(ctx, "nsca connection rejected, source not in allow list",
+				slog.String("source", conn.RemoteAddr().String()),
+			)
+
+			_ = conn.Close()
+
+			continue
+		}
+
+		metricConnections.WithLabelValues("accepted").Inc()
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+// handleConn sends the initialisation packet and then processes data packets until the client
+// closes the connection.
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	source := conn.RemoteAddr().String()
+	host := remoteHost(conn.RemoteAddr())
+	iv := make([]byte, IVSize)
+	_, _ = rand.Read(iv)
+
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if _, err := conn.Write(InitPacket(iv, time.Now())); err != nil {
+		s.Logger.WarnContext(ctx, "unable to send nsca init packet",
+			slog.String("source", source), slogtool.ErrorAttr(err),
+		)
+
+		return
+	}
+
+	for ctx.Err() == nil {
+		_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+		buf, err := s.readPacket(conn, iv)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			metricPackets.WithLabelValues("invalid").Inc()
+			s.Logger.WarnContext(ctx, "unable to read nsca packet",
+				slog.String("source", source), slogtool.ErrorAttr(err),
+			)
+
+			return
+		}
+
+		s.handlePacket(ctx, source, host, buf)
+	}
+}
+
+// readPacket reads and decrypts a data packet, the packet size is detected from the CRC32 so
+// clients built with either plugin output size are accepted.
+func (s *Server) readPacket(r io.Reader, iv []byte) ([]byte, error) {
+	buf := make([]byte, ShortPacketSize, LongPacketSize)
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err //nolint:wrapcheck // io.EOF is checked by the caller.
+	}
+
+	plain := bytes.Clone(buf)
+	s.encryption.Crypt(plain, iv, s.password)
+
+	if ValidCRC(plain) {
+		return plain, nil
+	}
+
+	buf = buf[:LongPacketSize]
+
+	if _, err := io.ReadFull(r, buf[ShortPacketSize:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCRC, err)
+	}
+
+	s.encryption.Crypt(buf, iv, s.password)
+
+	return buf, nil
+}
+
+// remoteHost returns the IP address of the remote end of a connection.
+func remoteHost(addr net.Addr) string {
+	if v, ok := addr.(*net.TCPAddr); ok {
+		return v.IP.String()
+	}
+
+	if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return host
+	}
+
+	return addr.String()
+}
+
+// handlePacket decodes a data packet from the connection with the source address and passes the
+// check result from host to the handler.
+func (s *Server) handlePacket(ctx context.Context, source, host string, buf []byte) {
+	pkt, err := Decode(buf)
+	if err != nil {
+		metricPackets.WithLabelValues("invalid").Inc()
+		s.Logger.WarnContext(ctx, "invalid nsca packet", slog.String("source", source), slogtool.ErrorAttr(err))
+
+		return
+	}
+
+	if age := time.Since(pkt.Timestamp); s.maxAge > 0 && (age > s.maxAge || age < -s.maxAge) {
+		metricPackets.WithLabelValues("expired").Inc()
+		s.Logger.WarnContext(ctx, "dropping nsca packet", slog.String("source", source),
+			slog.String("check.hostname", pkt.Hostname),
+			slog.String("check.name", pkt.Service),
+			slogtool.ErrorAttr(ErrPacketExpired),
+		)
+
+		return
+	}
+
+	if err = s.handler(ctx, host, pkt.EventMessage()); err != nil {
+		metricPackets.WithLabelValues("error").Inc()
+		s.Logger.ErrorContext(ctx, "unable to process nsca check result",
+			slog.String("source", source), slogtool.ErrorAttr(err),
+		)
+
+		return
+	}
+
+	metricPackets.WithLabelValues("ok").Inc()
+}
+
+// EventMessage converts the packet into an api.EventMessage.
+func (p *Packet) EventMessage() *api.EventMessage {
+	checkType := api.CheckType_SERVICE
+	if p.Service == "" {
+		checkType = api.CheckType_HOST
+	}
+
+	// send_nsca clients escape newlines in multi-line output.
+	output, longOutput, perfdata := checks.ParseOutput(strings.ReplaceAll(p.Output, `\n`, "\n"))
+	status := api.ExitCodeToStatus(p.ReturnCode)
+
+	return api.EventMessage_builder{
+		Id:               proto.String(uuid.New().String()),
+		Hostname:         proto.String(p.Hostname),
+		Type:             &checkType,
+		Check:            proto.String(p.Service),
+		Status:           &status,
+		Output:           proto.String(output),
+		LongOutput:       proto.String(longOutput),
+		Perfdata:         proto.String(perfdata),
+		RequestTimestamp: timestamppb.New(p.Timestamp),
+	}.Build()
+}
@@ -0,0 +1,97 @@
+package nsca_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/nsca"
+	"github.com/spf13/viper"
+)
+
+func TestServerReceivesPackets(t *testing.T) {
+	t.Parallel()
+
+	vcfg := viper.New()
+	vcfg.Set("nsca.encryption", "xor")
+	vcfg.Set("nsca.password", "secret")
+	vcfg.Set("nsca.allow", []string{"127.0.0.0/8"})
+	vcfg.Set("nsca.max-packet-age", "30s")
+	vcfg.Set("nsca.timeout", "5s")
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+	results := make(chan *api.EventMessage, 2)
+	sources := make(chan string, 2)
+
+	var buf bytes.Buffer
+
+	srv, err := nsca.NewServer(cfg, slog.New(slog.NewJSONHandler(&buf, nil)),
+		func(_ context.Context, source string, msg *api.EventMessage) error {
+			results <- msg
+			sources <- source
+
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("nsca.NewServer(): error, got '%s', want 'nil'", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): error, got '%s', want 'nil'", err)
+	}
+
+	go func() { _ = srv.Serve(ctx, lis) }()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatalf("net.Dial(): error, got '%s', want 'nil'", err)
+	}
+
+	defer conn.Close()
+
+	initPkt := make([]byte, nsca.InitPacketSize)
+	if _, err = io.ReadFull(conn, initPkt); err != nil {
+		t.Fatalf("reading init packet: error, got '%s', want 'nil'", err)
+	}
+
+	// send one packet of each size on the same connection.
+	for _, size := range []int{nsca.ShortOutputSize, nsca.LongOutputSize} {
+		pkt := testPacket()
+		pkt.Timestamp = time.Now()
+		data := pkt.Encode(size)
+		nsca.EncryptionXOR.Crypt(data, initPkt[:nsca.IVSize], []byte("secret"))
+
+		if _, err = conn.Write(data); err != nil {
+			t.Fatalf("writing packet: error, got '%s', want 'nil'", err)
+		}
+	}
+
+	for range 2 {
+		select {
+		case msg := <-results:
+			if msg.GetHostname() != "legacy.example.com" || msg.GetCheck() != "BACKUP" ||
+				msg.GetStatus() != api.Status_CRITICAL || msg.GetOutput() != "backup failed" ||
+				msg.GetPerfdata() != "size=0B" {
+				t.Errorf("unexpected check result: %v", msg)
+			}
+
+			// the source is used as a metric label, so it does not include the client port.
+			if source := <-sources; source != "127.0.0.1" {
+				t.Errorf("handler source: got '%s', want '127.0.0.1'", source)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("check result not received in time")
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/na4ma4/rsca/internal/checks"
 	"github.com/shirou/gopsutil/v3/host"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -30,24 +31,17 @@ func New(
 	checkList checks.Checks,
 	startTime time.Time,
 ) *Message {
-	checkNames := []string{}
-
-	for _, check := range checkList {
-		if check.Type == api.CheckType_SERVICE {
-			checkNames = append(checkNames, check.Name)
-		}
-	}
-
 	mb := api.Member_builder{
-		Id:           proto.String(uuid.New().String()),
-		Name:         proto.String(hostName),
-		Capability:   []string{"client", "rsca-" + versionInfo.GetBld().GetVersion()},
-		Service:      checkNames,
-		Tag:          cfg.GetStringSlice("general.tags"),
-		Version:      proto.String(versionInfo.GetBld().GetVersion()),
-		BuildDate:    proto.String(versionInfo.GetBld().GetDate().AsTime().Format(time.RFC3339)),
-		GitHash:      proto.String(versionInfo.GetGit().GetCommit()),
-		ProcessStart: timestamppb.New(startTime),
+		Id:            proto.String(uuid.New().String()),
+		Name:          proto.String(hostName),
+		Capability:    []string{"client", "rsca-" + versionInfo.GetBld().GetVersion()},
+		Service:       serviceNames(checkList),
+		ServicePeriod: servicePeriods(checkList),
+		Tag:           cfg.GetStringSlice("general.tags"),
+		Version:       proto.String(versionInfo.GetBld().GetVersion()),
+		BuildDate:     proto.String(versionInfo.GetBld().GetDate().AsTime().Format(time.RFC3339)),
+		GitHash:       proto.String(versionInfo.GetGit().GetCommit()),
+		ProcessStart:  timestamppb.New(startTime),
 	}.Build()
 
 	if ut, err := host.BootTimeWithContext(context.Background()); err == nil {
@@ -65,6 +59,32 @@ func New(
 	}
 }
 
+// serviceNames returns the names of the service checks in the check list.
+func serviceNames(checkList checks.Checks) []string {
+	checkNames := []string{}
+
+	for _, check := range checkList {
+		if check.Type == api.CheckType_SERVICE {
+			checkNames = append(checkNames, check.Name)
+		}
+	}
+
+	return checkNames
+}
+
+// servicePeriods returns the check period of each of the service checks in the check list.
+func servicePeriods(checkList checks.Checks) map[string]*durationpb.Duration {
+	periods := map[string]*durationpb.Duration{}
+
+	for _, check := range checkList {
+		if check.Type == api.CheckType_SERVICE {
+			periods[check.Name] = durationpb.New(check.Period)
+		}
+	}
+
+	return periods
+}
+
 // Message returns the actual api.RegisterMessage.
 func (msg *Message) Message() *api.RegisterMessage {
 	msg.lock.Lock()
@@ -102,3 +122,28 @@ func (msg *Message) UpdateInfoStat(ctx context.Context) {
 		msg.member.SetInfoStat(is)
 	}
 }
+
+// SetServer sets the address of the server the client is connected to.
+func (msg *Message) SetServer(server string) {
+	msg.lock.Lock()
+	defer msg.lock.Unlock()
+
+	msg.member.SetServer(server)
+}
+
+// SetServices updates the list of services and their check periods on the member from the check list.
+func (msg *Message) SetServices(checkList checks.Checks) {
+	msg.lock.Lock()
+	defer msg.lock.Unlock()
+
+	msg.member.SetService(serviceNames(checkList))
+	msg.member.SetServicePeriod(servicePeriods(checkList))
+}
+
+// SetTags updates the tags on the member.
+func (msg *Message) SetTags(tags []string) {
+	msg.lock.Lock()
+	defer msg.lock.Unlock()
+
+	msg.member.SetTag(tags)
+}
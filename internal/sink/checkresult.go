@@ -0,0 +1,193 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/rsca/api"
+)
+
+const (
+	// checkResultChars are the characters used in the random part of a checkresult file name.
+	checkResultChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// checkResultNameSize is the size of the random part of a checkresult file name, nagios only
+	// reads files named `c` followed by exactly six characters.
+	checkResultNameSize = 6
+
+	// checkResultAttempts is the number of random names tried before giving up.
+	checkResultAttempts = 100
+)
+
+// ErrCheckResultName is returned when no unused checkresult file name could be found.
+var ErrCheckResultName = errors.New("unable to find an unused nagios checkresult file name")
+
+// CheckResultDir is a Sink that writes check results as files in the Nagios `check_result_path`.
+type CheckResultDir struct {
+	Logger *slog.Logger
+	dir    string
+}
+
+// NewCheckResultDir returns a CheckResultDir sink that writes to the directory dir.
+func NewCheckResultDir(logger *slog.Logger, dir string) *CheckResultDir {
+	return &CheckResultDir{
+		Logger: logger,
+		dir:    dir,
+	}
+}
+
+// Write writes the check result to a `c` file followed by the `.ok` marker that tells Nagios the
+// file is complete.
+//
+// The result is written to a temporary file that Nagios ignores and renamed over a reserved `c`
+// file, so Nagios never reads a partially written result.
+func (s *CheckResultDir) Write(ctx context.Context, msg *api.EventMessage) error {
+	body, err := checkResultFile(msg)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".rsca-")
+	if err != nil {
+		return fmt.Errorf("create temporary nagios checkresult file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err = writeAndSync(tmp, body); err != nil {
+		return fmt.Errorf("write nagios checkresult file: %w", err)
+	}
+
+	reserved, err := s.reserve()
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), reserved); err != nil {
+		_ = os.Remove(reserved)
+
+		return fmt.Errorf("rename nagios checkresult file: %w", err)
+	}
+
+	if err = os.WriteFile(reserved+".ok", nil, permbits.MustString("u=rw,go=r")); err != nil {
+		_ = os.Remove(reserved)
+
+		return fmt.Errorf("create nagios checkresult marker: %w", err)
+	}
+
+	s.Logger.DebugContext(ctx, "wrote nagios checkresult file", slog.String("file", reserved))
+
+	return nil
+}
+
+// reserve creates an empty file with an unused name in the `cXXXXXX` format that nagios reads and
+// returns the path.
+func (s *CheckResultDir) reserve() (string, error) {
+	for range checkResultAttempts {
+		path := filepath.Join(s.dir, checkResultName())
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, permbits.MustString("u=rw,go=r"))
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("create nagios checkresult file: %w", err)
+		}
+
+		_ = f.Close()
+
+		return path, nil
+	}
+
+	return "", ErrCheckResultName
+}
+
+// checkResultName returns a random checkresult file name, `c` followed by six alphanumerics.
+func checkResultName() string {
+	b := make([]byte, 0, checkResultNameSize+1)
+	b = append(b, 'c')
+
+	for range checkResultNameSize {
+		b = append(b, checkResultChars[rand.IntN(len(checkResultChars))]) //nolint:gosec // names only need to be unique.
+	}
+
+	return string(b)
+}
+
+// writeAndSync writes the body to the file and flushes it to disk before closing it.
+func writeAndSync(f *os.File, body string) error {
+	if _, err := f.WriteString(body); err != nil {
+		_ = f.Close()
+
+		return err //nolint:wrapcheck // wrapped by caller.
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+
+		return err //nolint:wrapcheck // wrapped by caller.
+	}
+
+	if err := f.Chmod(permbits.MustString("u=rw,g=rw,o=r")); err != nil {
+		_ = f.Close()
+
+		return err //nolint:wrapcheck // wrapped by caller.
+	}
+
+	return f.Close() //nolint:wrapcheck // wrapped by caller.
+}
+
+// checkResultFile returns the contents of a checkresult file for the check result, the start time is
+// the time the check was run and the finish time adds the duration of the check.
+func checkResultFile(msg *api.EventMessage) (string, error) {
+	if msg.GetType() != api.CheckType_HOST && msg.GetType() != api.CheckType_SERVICE {
+		return "", fmt.Errorf("%w: %d", ErrUnknownMessageType, msg.GetType())
+	}
+
+	start := resultTime(msg)
+	finish := start
+
+	if msg.HasDuration() {
+		finish = start.Add(msg.GetDuration().AsDuration())
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("### Passive Check Result File ###\n")
+	fmt.Fprintf(&sb, "file_time=%d\n\n", time.Now().Unix())
+	sb.WriteString("### rsca Check Result ###\n")
+	fmt.Fprintf(&sb, "host_name=%s\n", msg.GetHostname())
+
+	if msg.GetType() == api.CheckType_SERVICE {
+		fmt.Fprintf(&sb, "service_description=%s\n", msg.GetCheck())
+	}
+
+	sb.WriteString("check_type=1\n")
+	sb.WriteString("check_options=0\n")
+	sb.WriteString("scheduled_check=0\n")
+	sb.WriteString("reschedule_check=0\n")
+	sb.WriteString("latency=0.0\n")
+	fmt.Fprintf(&sb, "start_time=%s\n", checkResultTime(start))
+	fmt.Fprintf(&sb, "finish_time=%s\n", checkResultTime(finish))
+	sb.WriteString("early_timeout=0\n")
+	sb.WriteString("exited_ok=1\n")
+	fmt.Fprintf(&sb, "return_code=%d\n", int32(msg.GetStatus()))
+	fmt.Fprintf(&sb, "output=%s\n", pluginOutput(msg))
+
+	return sb.String(), nil
+}
+
+// checkResultTime formats a time as `seconds.microseconds`.
+func checkResultTime(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/int(time.Microsecond))
+}
@@ -0,0 +1,174 @@
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/spf13/viper"
+)
+
+const (
+	// TypeNagiosCommand writes to the Nagios external command file.
+	TypeNagiosCommand = "nagios-command"
+
+	// TypeNagiosCheckResult writes files to the Nagios checkresult spool directory.
+	TypeNagiosCheckResult = "nagios-checkresult"
+
+	// TypeJSONLines appends to a JSON-lines file.
+	TypeJSONLines = "jsonl"
+
+	// TypeWebhook POSTs to a HTTP webhook.
+	TypeWebhook = "webhook"
+)
+
+const (
+	// defaultSinkName is the name of the sink used when no sinks are configured.
+	defaultSinkName = "nagios"
+
+	// defaultWebhookTimeout is the webhook request timeout when `sink.<name>.timeout` is not set.
+	defaultWebhookTimeout = 10 * time.Second
+
+	// defaultWebhookQueueSize is the webhook queue size when `sink.<name>.queue-size` is not set.
+	defaultWebhookQueueSize = 1000
+)
+
+var (
+	// ErrUnknownSinkType is returned when a sink is configured with a type that does not exist.
+	ErrUnknownSinkType = errors.New("unknown sink type")
+
+	// ErrMissingOption is returned when a required sink option is not configured.
+	ErrMissingOption = errors.New("missing sink option")
+
+	// ErrUnknownResultMode is returned when `nagios.result-mode` is not a supported mode.
+	ErrUnknownResultMode = errors.New("unknown nagios result mode")
+)
+
+// GetChainFromViper returns a Chain of the `sink.<name>` sections in the config, when no sinks are
+// configured the chain writes to nagios using the `nagios.result-mode`.
+func GetChainFromViper(cfg config.Conf, vcfg *viper.Viper, logger *slog.Logger) (*Chain, error) {
+	names := []string{}
+
+	for _, key := range vcfg.AllKeys() {
+		if strings.HasPrefix(key, "sink.") {
+			token := strings.SplitN(key, ".", 3) //nolint:mnd // sink keys come in 3 parts.
+			if !slices.Contains(names, token[1]) {
+				names = append(names, token[1])
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	chain := NewChain(logger)
+
+	if len(names) == 0 {
+		s, err := defaultSink(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		chain.Add(defaultSinkName, s, Filter{}, false)
+
+		return chain, nil
+	}
+
+	for _, name := range names {
+		s, err := GetSinkFromViper(cfg, logger, name)
+		if err != nil {
+			return nil, fmt.Errorf("sink '%s': %w", name, err)
+		}
+
+		chain.Add(name, s, Filter{
+			Hosts:      cfg.GetStringSlice(fmt.Sprintf("sink.%s.hosts", name)),
+			Tags:       cfg.GetStringSlice(fmt.Sprintf("sink.%s.tags", name)),
+			SoftStates: softStates(cfg, name),
+		}, cfg.GetBool(fmt.Sprintf("sink.%s.ignore-errors", name)))
+
+		logger.Info("adding result sink", slog.String("sink.name", name),
+			slog.String("sink.type", cfg.GetString(fmt.Sprintf("sink.%s.type", name))))
+	}
+
+	return chain, nil
+}
+
+// defaultSink returns the nagios sink selected by `nagios.result-mode`, either the external
+// command file (`command`) or the checkresult spool directory (`checkresult`).
+func defaultSink(cfg config.Conf, logger *slog.Logger) (Sink, error) {
+	switch mode := strings.ToLower(cfg.GetString("nagios.result-mode")); mode {
+	case "", "command":
+		return NewCommandFile(logger, defaultSinkName, cfg.GetString("nagios.command-file"), commandFileOptions(cfg)), nil
+	case "checkresult":
+		return NewCheckResultDir(logger, cfg.GetString("nagios.checkresult-path")), nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownResultMode, mode)
+	}
+}
+
+// GetSinkFromViper returns the sink with the specified name from the config file.
+func GetSinkFromViper(cfg config.Conf, logger *slog.Logger, name string) (Sink, error) {
+	path := cfg.GetString(fmt.Sprintf("sink.%s.path", name))
+
+	switch sinkType := strings.ToLower(cfg.GetString(fmt.Sprintf("sink.%s.type", name))); sinkType {
+	case TypeNagiosCommand:
+		if path == "" {
+			path = cfg.GetString("nagios.command-file")
+		}
+
+		return NewCommandFile(logger, name, path, commandFileOptions(cfg)), nil
+	case TypeNagiosCheckResult:
+		if path == "" {
+			path = cfg.GetString("nagios.checkresult-path")
+		}
+
+		return NewCheckResultDir(logger, path), nil
+	case TypeJSONLines:
+		if path == "" {
+			return nil, fmt.Errorf("%w: path", ErrMissingOption)
+		}
+
+		return NewJSONLines(path), nil
+	case TypeWebhook:
+		url := cfg.GetString(fmt.Sprintf("sink.%s.url", name))
+		if url == "" {
+			return nil, fmt.Errorf("%w: url", ErrMissingOption)
+		}
+
+		timeout := cfg.GetDuration(fmt.Sprintf("sink.%s.timeout", name))
+		if timeout == 0 {
+			timeout = defaultWebhookTimeout
+		}
+
+		queueSize := cfg.GetInt(fmt.Sprintf("sink.%s.queue-size", name))
+		if queueSize == 0 {
+			queueSize = defaultWebhookQueueSize
+		}
+
+		return NewWebhook(
+			logger, name, url, cfg.GetStringSlice(fmt.Sprintf("sink.%s.headers", name)), timeout, queueSize,
+		)
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownSinkType, sinkType)
+	}
+}
+
+// softStates returns `sink.<name>.soft-states`, soft states are excluded from every sink unless it
+// is enabled so only hard states are reported.
+func softStates(cfg config.Conf, name string) bool {
+	return cfg.GetBool(fmt.Sprintf("sink.%s.soft-states", name))
+}
+
+// commandFileOptions returns the command file queue settings from the `nagios` config section.
+func commandFileOptions(cfg config.Conf) CommandFileOptions {
+	return CommandFileOptions{
+		QueueSize:         cfg.GetInt("nagios.queue-size"),
+		BatchSize:         cfg.GetInt("nagios.batch-size"),
+		OpenTimeout:       cfg.GetDuration("nagios.open-timeout"),
+		WriteTimeout:      cfg.GetDuration("nagios.write-timeout"),
+		ReconnectInterval: cfg.GetDuration("nagios.reconnect-interval"),
+	}
+}
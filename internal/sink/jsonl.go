@@ -0,0 +1,50 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// JSONLines is a Sink that appends check results to a file with one JSON object per line.
+type JSONLines struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewJSONLines returns a JSONLines sink that appends to the file at path.
+func NewJSONLines(path string) *JSONLines {
+	return &JSONLines{
+		path: path,
+	}
+}
+
+// Write appends the check result to the file, the file is opened for each write so it can be
+// rotated externally.
+func (s *JSONLines) Write(_ context.Context, msg *api.EventMessage) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal check result: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permbits.MustString("u=rw,go=r"))
+	if err != nil {
+		return fmt.Errorf("open json lines file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write json lines file: %w", err)
+	}
+
+	return nil
+}
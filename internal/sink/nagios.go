@@ -0,0 +1,322 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "command_queue_depth",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of commands waiting to be written to the nagios command file",
+	}, []string{"sink"})
+	metricDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "commands_dropped_total",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of commands dropped because the nagios command queue was full",
+	}, []string{"sink"})
+	metricOpens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "command_file_opens_total",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of times the nagios command file was opened by result",
+	}, []string{"sink", "result"})
+)
+
+// CommandFileOptions are the queue and timeout settings of a CommandFile.
+type CommandFileOptions struct {
+	// QueueSize is the number of commands that can be waiting to be written.
+	QueueSize int
+
+	// BatchSize is the maximum number of commands written at once.
+	BatchSize int
+
+	// OpenTimeout is how long to wait for a reader on the command pipe before retrying.
+	OpenTimeout time.Duration
+
+	// WriteTimeout is how long a write can block before the command file is reopened.
+	WriteTimeout time.Duration
+
+	// ReconnectInterval is the delay between attempts to open the command file.
+	ReconnectInterval time.Duration
+}
+
+// CommandFile is a Sink that writes check results to the Nagios external command file.
+//
+// Commands are queued and written by a single routine that keeps the command file open, so a
+// Nagios that is not reading the command pipe does not block the callers of Enqueue.
+type CommandFile struct {
+	Logger *slog.Logger
+	name   string
+	path   string
+	opts   CommandFileOptions
+	queue  chan queuedCommand
+	file   *os.File
+}
+
+// queuedCommand is a command waiting to be written, done is called once it has been written or
+// could not be written.
+type queuedCommand struct {
+	data string
+	done func(error)
+}
+
+// NewCommandFile returns a CommandFile sink that writes to the command file at path, the Run
+// routine must be started for the commands to be written.
+func NewCommandFile(logger *slog.Logger, name, path string, opts CommandFileOptions) *CommandFile {
+	return &CommandFile{
+		Logger: logger,
+		name:   name,
+		path:   path,
+		opts:   opts,
+		queue:  make(chan queuedCommand, max(opts.QueueSize, 1)),
+	}
+}
+
+// Write writes the check result to the command file and waits until it has been written.
+func (s *CommandFile) Write(ctx context.Context, msg *api.EventMessage) error {
+	return waitEnqueue(ctx, s, msg)
+}
+
+// Enqueue queues the check result as a PROCESS_HOST_CHECK_RESULT or PROCESS_SERVICE_CHECK_RESULT
+// command, done is called once the command has been written to the command file. ErrQueueFull is
+// returned when the queue is full.
+func (s *CommandFile) Enqueue(ctx context.Context, msg *api.EventMessage, done func(error)) error {
+	command, err := checkResultCommand(msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.queue <- queuedCommand{data: fmt.Sprintf("[%d] %s\n", resultTime(msg).Unix(), command), done: done}:
+		metricQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+		s.Logger.DebugContext(ctx, "queued nagios command", slog.String("command", command))
+
+		return nil
+	default:
+		metricDropped.WithLabelValues(s.name).Inc()
+
+		return ErrQueueFull
+	}
+}
+
+// checkResultCommand returns the external command for the check result.
+func checkResultCommand(msg *api.EventMessage) (string, error) {
+	status := int32(msg.GetStatus())
+
+	switch msg.GetType() {
+	case api.CheckType_HOST:
+		return fmt.Sprintf(
+			"PROCESS_HOST_CHECK_RESULT;%s;%d;%s",
+			msg.GetHostname(),
+			status,
+			pluginOutput(msg),
+		), nil
+	case api.CheckType_SERVICE:
+		return fmt.Sprintf(
+			"PROCESS_SERVICE_CHECK_RESULT;%s;%s;%d;%s",
+			msg.GetHostname(),
+			msg.GetCheck(),
+			status,
+			pluginOutput(msg),
+		), nil
+	default:
+		return "", fmt.Errorf("%w: %d", ErrUnknownMessageType, msg.GetType())
+	}
+}
+
+// Run is a routine that writes the queued commands to the command file until the context is
+// cancelled, the commands still queued are then written before it returns.
+func (s *CommandFile) Run(ctx context.Context) func() error {
+	return func() error {
+		defer s.close()
+
+		// commands are written until the open and write timeouts after the context is cancelled, so
+		// the commands still queued at shutdown are written instead of failing.
+		writeCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+
+		stop := context.AfterFunc(ctx, func() { time.AfterFunc(s.opts.OpenTimeout+s.opts.WriteTimeout, cancel) })
+		defer stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.drain(writeCtx)
+
+				return nil
+			case command := <-s.queue:
+				s.writeBatch(writeCtx, s.batch(command))
+			}
+		}
+	}
+}
+
+// drain writes the commands still queued, commands that can not be written before the context is
+// cancelled fail so their results are not acknowledged.
+func (s *CommandFile) drain(ctx context.Context) {
+	for {
+		select {
+		case command := <-s.queue:
+			s.writeBatch(ctx, s.batch(command))
+		default:
+			return
+		}
+	}
+}
+
+// batch returns the command with any other queued commands, up to the batch size.
+func (s *CommandFile) batch(command queuedCommand) []queuedCommand {
+	out := []queuedCommand{command}
+
+	for range max(s.opts.BatchSize, 1) - 1 {
+		select {
+		case next := <-s.queue:
+			out = append(out, next)
+		default:
+			metricQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+
+			return out
+		}
+	}
+
+	metricQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+
+	return out
+}
+
+// writeBatch writes the commands to the command file and calls their done functions, the file is
+// reopened until all of the commands have been written or the context is cancelled.
+func (s *CommandFile) writeBatch(ctx context.Context, commands []queuedCommand) {
+	var data []byte
+	for _, command := range commands {
+		data = append(data, command.data...)
+	}
+
+	err := s.write(ctx, data)
+
+	for _, command := range commands {
+		if command.done != nil {
+			command.done(err)
+		}
+	}
+}
+
+// write writes the data to the command file, reopening the file until all of the data has been
+// written or the context is cancelled.
+func (s *CommandFile) write(ctx context.Context, data []byte) error {
+	for len(data) > 0 {
+		if s.file == nil {
+			if err := s.open(ctx); err != nil {
+				metricOpens.WithLabelValues(s.name, "error").Inc()
+				s.Logger.WarnContext(ctx, "unable to open nagios command file",
+					slog.String("sink", s.name), slog.String("path", s.path), slogtool.ErrorAttr(err),
+				)
+
+				if !sleepContext(ctx, s.opts.ReconnectInterval) {
+					return fmt.Errorf("unable to write nagios command file: %w", ctx.Err())
+				}
+
+				continue
+			}
+
+			metricOpens.WithLabelValues(s.name, "ok").Inc()
+		}
+
+		if s.opts.WriteTimeout > 0 {
+			// deadlines are only supported on pipes, regular files return os.ErrNoDeadline.
+			_ = s.file.SetWriteDeadline(time.Now().Add(s.opts.WriteTimeout))
+		}
+
+		n, err := s.file.Write(data)
+		data = data[n:]
+
+		if err != nil {
+			s.Logger.WarnContext(ctx, "unable to write to nagios command file, reopening",
+				slog.String("sink", s.name), slog.String("path", s.path), slogtool.ErrorAttr(err),
+			)
+			s.close()
+		}
+	}
+
+	return nil
+}
+
+// open opens the command file, a named pipe is opened without blocking so it is retried until a
+// reader (Nagios) is available or the open timeout is reached.
+func (s *CommandFile) open(ctx context.Context) error {
+	st, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("open command file for nagios: %w", err)
+	}
+
+	if st.Mode()&os.ModeNamedPipe == 0 {
+		f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, permbits.UserRead+permbits.UserWrite)
+		if err != nil {
+			return fmt.Errorf("open command file for nagios: %w", err)
+		}
+
+		s.file = f
+
+		return nil
+	}
+
+	deadline := time.Now().Add(s.opts.OpenTimeout)
+
+	for {
+		f, err := os.OpenFile(s.path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+		if err == nil {
+			s.file = f
+
+			return nil
+		}
+
+		// ENXIO is returned when there is no process reading the pipe.
+		if !errors.Is(err, syscall.ENXIO) || time.Now().After(deadline) {
+			return fmt.Errorf("open command pipe for nagios: %w", err)
+		}
+
+		if !sleepContext(ctx, openRetryInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// close closes the command file so it is reopened on the next write.
+func (s *CommandFile) close() {
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+}
+
+// openRetryInterval is the delay between attempts to open a command pipe without a reader.
+const openRetryInterval = 100 * time.Millisecond
+
+// sleepContext waits for the duration, returning false if the context is cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,84 @@
+//go:build !windows
+
+package sink_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/sink"
+)
+
+func TestCommandFilePipeReconnect(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nagios.cmd")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Fatalf("syscall.Mkfifo(): error, got '%s', want 'nil'", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := sink.NewCommandFile(slog.New(slog.NewJSONHandler(io.Discard, nil)), "fifo", path, sink.CommandFileOptions{
+		QueueSize:         10,
+		BatchSize:         10,
+		OpenTimeout:       100 * time.Millisecond,
+		WriteTimeout:      time.Second,
+		ReconnectInterval: 50 * time.Millisecond,
+	})
+
+	go func() { _ = s.Run(ctx)() }()
+
+	// there is no reader on the pipe yet, the command is queued and done is called once it is written.
+	done := make(chan error, 1)
+	if err := s.Enqueue(ctx, testMessage("web01", api.Status_OK), func(err error) { done <- err }); err != nil {
+		t.Fatalf("CommandFile.Enqueue(): error, got '%s', want 'nil'", err)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("CommandFile.Enqueue(): done called before the pipe was read, got '%v'", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	for _, host := range []string{"web01", "web02"} {
+		reader, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			t.Fatalf("opening pipe: error, got '%s', want 'nil'", err)
+		}
+
+		if host == "web02" {
+			if err = s.Write(ctx, testMessage(host, api.Status_OK)); err != nil {
+				t.Fatalf("CommandFile.Write(): error, got '%s', want 'nil'", err)
+			}
+		}
+
+		line, err := bufio.NewReader(reader).ReadString('\n')
+		if host == "web01" {
+			if derr := <-done; derr != nil {
+				t.Errorf("CommandFile.Enqueue(): done error, got '%s', want 'nil'", derr)
+			}
+		}
+
+		if err != nil {
+			t.Fatalf("reading pipe: error, got '%s', want 'nil'", err)
+		}
+
+		if !strings.Contains(line, ";"+host+";") {
+			t.Errorf("reading pipe: got '%s', want command for '%s'", line, host)
+		}
+
+		// the reader going away (eg. nagios restarting) causes the next write to reopen the pipe.
+		_ = reader.Close()
+	}
+}
@@ -0,0 +1,87 @@
+package sink_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/sink"
+)
+
+func TestCommandFileQueueFull(t *testing.T) {
+	t.Parallel()
+
+	s := sink.NewCommandFile(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)), "full",
+		filepath.Join(t.TempDir(), "nagios.cmd"), sink.CommandFileOptions{QueueSize: 2},
+	)
+
+	// the writer routine is not running so the queue is never drained.
+	for range 2 {
+		if err := s.Enqueue(context.Background(), testMessage("web01", api.Status_OK), nil); err != nil {
+			t.Fatalf("CommandFile.Enqueue(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	err := s.Enqueue(context.Background(), testMessage("web01", api.Status_OK), nil)
+	if !errors.Is(err, sink.ErrQueueFull) {
+		t.Errorf("CommandFile.Enqueue(): error got '%v', want '%s'", err, sink.ErrQueueFull)
+	}
+}
+
+func TestCommandFileDrainOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nagios.cmd")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("os.WriteFile(): error, got '%s', want 'nil'", err)
+	}
+
+	s := sink.NewCommandFile(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)), "drain", path,
+		sink.CommandFileOptions{QueueSize: 10, BatchSize: 2, OpenTimeout: time.Second, WriteTimeout: time.Second},
+	)
+
+	var written []string
+	for _, host := range []string{"web01", "web02", "web03"} {
+		if err := s.Enqueue(context.Background(), testMessage(host, api.Status_OK), func(err error) {
+			if err != nil {
+				t.Errorf("CommandFile.Enqueue(): done error, got '%s', want 'nil'", err)
+			}
+
+			written = append(written, host)
+		}); err != nil {
+			t.Fatalf("CommandFile.Enqueue(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	// the context is already cancelled, the queued commands are still written before Run returns.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.Run(ctx)(); err != nil {
+		t.Fatalf("CommandFile.Run(): error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff([]string{"web01", "web02", "web03"}, written); diff != "" {
+		t.Errorf("CommandFile.Run(): done mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(): error, got '%s', want 'nil'", err)
+	}
+
+	if got := strings.Count(string(data), "\n"); got != 3 {
+		t.Errorf("os.ReadFile(): commands got '%d', want '3'", got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package sink contains the destinations that rscad writes check results to, results are passed
+// through a configurable chain of sinks so the same results can feed Nagios and other systems.
+package sink
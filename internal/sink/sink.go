@@ -0,0 +1,284 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/multierr"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	// ErrUnknownMessageType is returned when a message is of unknown type.
+	ErrUnknownMessageType = errors.New("unknown message type")
+
+	// ErrQueueFull is returned when the queue of a sink is full, the check result is dropped.
+	ErrQueueFull = errors.New("sink queue is full")
+)
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "results_total",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of check results by sink and result",
+	}, []string{"sink", "result"})
+	metricWriteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:      "write_duration_seconds",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "time taken to write a check result by sink",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"sink"})
+)
+
+// Sink writes check results to a destination.
+type Sink interface {
+	// Write writes a single check result.
+	Write(ctx context.Context, msg *api.EventMessage) error
+}
+
+// QueuedSink is implemented by sinks that queue check results for a background routine.
+type QueuedSink interface {
+	Sink
+
+	// Enqueue queues a single check result, done is called once the result has been written or could
+	// not be written.
+	Enqueue(ctx context.Context, msg *api.EventMessage, done func(error)) error
+}
+
+// Runner is implemented by sinks that have a background routine.
+type Runner interface {
+	// Run returns the routine, it must return when the context is cancelled.
+	Run(ctx context.Context) func() error
+}
+
+// Filter limits the check results that are written to a sink.
+type Filter struct {
+	// Hosts are hostname patterns (eg. `web*`), an empty list matches all hosts.
+	Hosts []string
+
+	// Tags match results from hosts with any of the tags, an empty list matches all hosts.
+	Tags []string
+
+	// SoftStates includes results that are in a soft state.
+	SoftStates bool
+}
+
+// Match returns true if the check result from a host with the supplied tags passes the filter.
+func (f Filter) Match(msg *api.EventMessage, tags []string) bool {
+	if msg.IsSoftState() && !f.SoftStates {
+		return false
+	}
+
+	if len(f.Hosts) > 0 && !slices.ContainsFunc(f.Hosts, func(pattern string) bool {
+		ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(msg.GetHostname()))
+
+		return ok
+	}) {
+		return false
+	}
+
+	if len(f.Tags) > 0 && !slices.ContainsFunc(f.Tags, func(tag string) bool {
+		return slices.ContainsFunc(tags, func(v string) bool { return strings.EqualFold(v, tag) })
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// entry is a sink in a chain.
+type entry struct {
+	name         string
+	sink         Sink
+	filter       Filter
+	ignoreErrors bool
+}
+
+// Chain writes check results to each of the sinks whose filter matches.
+type Chain struct {
+	Logger *slog.Logger
+	sinks  []*entry
+}
+
+// NewChain returns an empty Chain.
+func NewChain(logger *slog.Logger) *Chain {
+	return &Chain{
+		Logger: logger,
+	}
+}
+
+// Add appends a sink to the chain, errors from the sink are only logged when ignoreErrors is true.
+func (c *Chain) Add(name string, s Sink, filter Filter, ignoreErrors bool) {
+	c.sinks = append(c.sinks, &entry{
+		name:         name,
+		sink:         s,
+		filter:       filter,
+		ignoreErrors: ignoreErrors,
+	})
+}
+
+// Names returns the names of the sinks in the chain.
+func (c *Chain) Names() []string {
+	out := make([]string, 0, len(c.sinks))
+	for _, e := range c.sinks {
+		out = append(out, e.name)
+	}
+
+	return out
+}
+
+// Run is a routine that runs the background routines of the sinks until the context is cancelled.
+func (c *Chain) Run(ctx context.Context) func() error {
+	return func() error {
+		eg, ctx := errgroup.WithContext(ctx)
+
+		for _, e := range c.sinks {
+			if r, ok := e.sink.(Runner); ok {
+				eg.Go(r.Run(ctx))
+			}
+		}
+
+		return eg.Wait() //nolint:wrapcheck // errors are from the sinks.
+	}
+}
+
+// Write writes the check result from a host with the supplied tags to each matching sink and waits
+// until every sink has finished, the errors of the sinks that do not ignore errors are returned.
+func (c *Chain) Write(ctx context.Context, msg *api.EventMessage, tags []string) error {
+	errCh := make(chan error, 1)
+
+	c.WriteAsync(ctx, msg, tags, func(err error) { errCh <- err })
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WriteAsync writes the check result from a host with the supplied tags to each matching sink, every
+// sink is attempted and done is called once with the errors of the sinks that do not ignore errors
+// after the queued sinks have written the result.
+func (c *Chain) WriteAsync(ctx context.Context, msg *api.EventMessage, tags []string, done func(error)) {
+	var (
+		lock    sync.Mutex
+		errs    error
+		pending = 1
+	)
+
+	finish := func(e *entry, start time.Time, err error) {
+		if e != nil {
+			c.record(ctx, e, msg, start, err)
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if err != nil && e != nil && !e.ignoreErrors {
+			errs = multierr.Append(errs, err)
+		}
+
+		pending--
+		if pending == 0 {
+			done(errs)
+		}
+	}
+
+	for _, e := range c.sinks {
+		if !e.filter.Match(msg, tags) {
+			metricResults.WithLabelValues(e.name, "filtered").Inc()
+
+			continue
+		}
+
+		start := time.Now()
+
+		if q, ok := e.sink.(QueuedSink); ok {
+			lock.Lock()
+			pending++
+			lock.Unlock()
+
+			if err := q.Enqueue(ctx, msg, func(err error) { finish(e, start, err) }); err != nil {
+				finish(e, start, err)
+			}
+
+			continue
+		}
+
+		lock.Lock()
+		pending++
+		lock.Unlock()
+
+		finish(e, start, e.sink.Write(ctx, msg))
+	}
+
+	finish(nil, time.Time{}, nil)
+}
+
+// record updates the metrics and logs the error of a write to a sink.
+func (c *Chain) record(ctx context.Context, e *entry, msg *api.EventMessage, start time.Time, err error) {
+	metricWriteDuration.WithLabelValues(e.name).Observe(time.Since(start).Seconds())
+
+	if err == nil {
+		metricResults.WithLabelValues(e.name, "written").Inc()
+
+		return
+	}
+
+	metricResults.WithLabelValues(e.name, "error").Inc()
+	c.Logger.ErrorContext(ctx, "unable to write check result to sink",
+		slog.String("sink", e.name),
+		slog.String("response.id", msg.GetId()),
+		slog.String("check.hostname", msg.GetHostname()),
+		slog.String("check.name", msg.GetCheck()),
+		slogtool.ErrorAttr(err),
+	)
+}
+
+// waitEnqueue queues the check result on the sink and waits until it has been written.
+func waitEnqueue(ctx context.Context, s QueuedSink, msg *api.EventMessage) error {
+	errCh := make(chan error, 1)
+
+	if err := s.Enqueue(ctx, msg, func(err error) { errCh <- err }); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// resultTime returns the time the check was run, results replayed from a client spool keep the
+// time the check was run.
+func resultTime(msg *api.EventMessage) time.Time {
+	if msg.HasRequestTimestamp() {
+		return msg.GetRequestTimestamp().AsTime()
+	}
+
+	return time.Now()
+}
+
+// pluginOutput rebuilds the plugin output from the output, perfdata and long output of a message,
+// newlines are escaped so the output stays on a single line.
+func pluginOutput(msg *api.EventMessage) string {
+	out := strings.ReplaceAll(msg.PluginOutput(), "\r", "")
+
+	return strings.ReplaceAll(out, "\n", `\n`)
+}
@@ -0,0 +1,252 @@
+package sink_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/sink"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errTestSink = errors.New("sink failed")
+
+type failSink struct{}
+
+func (failSink) Write(context.Context, *api.EventMessage) error { return errTestSink }
+
+func testMessage(hostname string, status api.Status) *api.EventMessage {
+	return api.EventMessage_builder{
+		Id:               proto.String("test-id"),
+		Hostname:         proto.String(hostname),
+		Type:             api.CheckType_SERVICE.Enum(),
+		Check:            proto.String("DISK"),
+		Status:           &status,
+		Output:           proto.String("DISK WARNING - 91% used"),
+		LongOutput:       proto.String("/ 91%\n/var 10%"),
+		Perfdata:         proto.String("'/'=91%;90;95"),
+		RequestTimestamp: timestamppb.New(time.Unix(1700000000, 500000000)),
+		Duration:         durationpb.New(1500 * time.Millisecond),
+	}.Build()
+}
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	soft := testMessage("web01", api.Status_WARNING)
+	soft.SetMaxRetries(3)
+
+	tests := []struct {
+		name   string
+		filter sink.Filter
+		msg    *api.EventMessage
+		tags   []string
+		want   bool
+	}{
+		{"empty", sink.Filter{}, testMessage("web01", api.Status_OK), nil, true},
+		{"host match", sink.Filter{Hosts: []string{"WEB*"}}, testMessage("web01", api.Status_OK), nil, true},
+		{"host mismatch", sink.Filter{Hosts: []string{"db*"}}, testMessage("web01", api.Status_OK), nil, false},
+		{"tag match", sink.Filter{Tags: []string{"prod"}}, testMessage("web01", api.Status_OK), []string{"Prod"}, true},
+		{"tag mismatch", sink.Filter{Tags: []string{"prod"}}, testMessage("web01", api.Status_OK), []string{"dev"}, false},
+		{"soft excluded", sink.Filter{}, soft, nil, false},
+		{"soft included", sink.Filter{SoftStates: true}, soft, nil, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Match(tt.msg, tt.tags); got != tt.want {
+			t.Errorf("Filter.Match(%s): got '%t', want '%t'", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChainSoftStateDefaults(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cmdFile := filepath.Join(dir, "nagios.cmd")
+	jsonFile := filepath.Join(dir, "results.jsonl")
+	softFile := filepath.Join(dir, "soft.jsonl")
+
+	vcfg := viper.New()
+	vcfg.Set("sink.nagios.type", sink.TypeNagiosCommand)
+	vcfg.Set("sink.nagios.path", cmdFile)
+	vcfg.Set("sink.jsonl.type", sink.TypeJSONLines)
+	vcfg.Set("sink.jsonl.path", jsonFile)
+	vcfg.Set("sink.soft.type", sink.TypeJSONLines)
+	vcfg.Set("sink.soft.path", softFile)
+	vcfg.Set("sink.soft.soft-states", true)
+
+	cfg := config.NewViperConfigFromViper(vcfg, "rsca-not-used")
+
+	chain, err := sink.GetChainFromViper(cfg, vcfg, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("sink.GetChainFromViper(): error, got '%s', want 'nil'", err)
+	}
+
+	soft := testMessage("web01", api.Status_WARNING)
+	soft.SetMaxRetries(3)
+
+	// sinks exclude soft states by default, so the write does not wait for the nagios routine.
+	if err = chain.Write(context.Background(), soft, nil); err != nil {
+		t.Fatalf("Chain.Write(): error, got '%s', want 'nil'", err)
+	}
+
+	if _, err = os.Stat(cmdFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("command file: got '%v', want '%s'", err, os.ErrNotExist)
+	}
+
+	jsonl, _ := os.ReadFile(jsonFile)
+	if lines := strings.Count(string(jsonl), "\n"); lines != 0 {
+		t.Errorf("jsonl file: lines got '%d', want '%d'", lines, 0)
+	}
+
+	jsonl, _ = os.ReadFile(softFile)
+	if lines := strings.Count(string(jsonl), "\n"); lines != 1 {
+		t.Errorf("soft-states jsonl file: lines got '%d', want '%d'", lines, 1)
+	}
+}
+
+func TestChainWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	cmdFile := filepath.Join(dir, "nagios.cmd")
+	jsonFile := filepath.Join(dir, "results.jsonl")
+
+	if err := os.WriteFile(cmdFile, nil, 0o600); err != nil {
+		t.Fatalf("os.WriteFile(): error, got '%s', want 'nil'", err)
+	}
+
+	var webhookBody bytes.Buffer
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(&webhookBody, r.Body)
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	webhook, err := sink.NewWebhook(logger, "webhook", srv.URL, []string{"Authorization: Bearer token"}, time.Second, 10)
+	if err != nil {
+		t.Fatalf("sink.NewWebhook(): error, got '%s', want 'nil'", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chain := sink.NewChain(logger)
+	chain.Add("nagios", sink.NewCommandFile(logger, "nagios", cmdFile, sink.CommandFileOptions{QueueSize: 10}), sink.Filter{}, false)
+	chain.Add("jsonl", sink.NewJSONLines(jsonFile), sink.Filter{Tags: []string{"prod"}}, false)
+	chain.Add("webhook", webhook, sink.Filter{}, false)
+	chain.Add("broken", failSink{}, sink.Filter{}, true)
+
+	go func() { _ = chain.Run(ctx)() }()
+
+	if err = chain.Write(ctx, testMessage("web01", api.Status_WARNING), []string{"prod"}); err != nil {
+		t.Fatalf("Chain.Write(): error, got '%s', want 'nil'", err)
+	}
+
+	if err = chain.Write(ctx, testMessage("web02", api.Status_OK), nil); err != nil {
+		t.Fatalf("Chain.Write(): error, got '%s', want 'nil'", err)
+	}
+
+	cmd := waitForFile(t, cmdFile, 2)
+	wantCmd := "[1700000000] PROCESS_SERVICE_CHECK_RESULT;web01;DISK;1;DISK WARNING - 91% used|'/'=91%;90;95\\n/ 91%\\n/var 10%\n" +
+		"[1700000000] PROCESS_SERVICE_CHECK_RESULT;web02;DISK;0;DISK WARNING - 91% used|'/'=91%;90;95\\n/ 91%\\n/var 10%\n"
+
+	if diff := cmp.Diff(wantCmd, cmd); diff != "" {
+		t.Errorf("command file: -want +got:\n%s", diff)
+	}
+
+	jsonl, _ := os.ReadFile(jsonFile)
+	if lines := strings.Count(string(jsonl), "\n"); lines != 1 {
+		t.Errorf("jsonl file: lines got '%d', want '%d'", lines, 1)
+	}
+
+	if !strings.Contains(webhookBody.String(), `"web02"`) {
+		t.Errorf("webhook body: got '%s', want to contain 'web02'", webhookBody.String())
+	}
+
+	chain.Add("required", failSink{}, sink.Filter{}, false)
+
+	if err = chain.Write(ctx, testMessage("web01", api.Status_OK), nil); !errors.Is(err, errTestSink) {
+		t.Errorf("Chain.Write(): error got '%v', want '%s'", err, errTestSink)
+	}
+}
+
+// waitForFile waits for the file to contain the number of lines and returns the contents.
+func waitForFile(t *testing.T, path string, lines int) string {
+	t.Helper()
+
+	for range 100 {
+		if data, _ := os.ReadFile(path); strings.Count(string(data), "\n") >= lines {
+			return string(data)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	data, _ := os.ReadFile(path)
+	t.Fatalf("waiting for '%s': got '%s', want '%d' lines", path, data, lines)
+
+	return ""
+}
+
+func TestCheckResultDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s := sink.NewCheckResultDir(slog.New(slog.NewJSONHandler(io.Discard, nil)), dir)
+
+	if err := s.Write(context.Background(), testMessage("web01", api.Status_CRITICAL)); err != nil {
+		t.Fatalf("CheckResultDir.Write(): error, got '%s', want 'nil'", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 2 {
+		t.Fatalf("checkresult files: got '%v', want only a result file and marker", files)
+	}
+
+	// nagios only reads checkresult files named `c` followed by exactly six characters.
+	if name := filepath.Base(files[0]); !regexp.MustCompile(`^c[0-9A-Za-z]{6}$`).MatchString(name) {
+		t.Errorf("checkresult file name: got '%s', want to match '^c[0-9A-Za-z]{6}$'", name)
+	}
+
+	body, _ := os.ReadFile(files[0])
+
+	for _, want := range []string{
+		"host_name=web01\n",
+		"service_description=DISK\n",
+		"start_time=1700000000.500000\n",
+		"finish_time=1700000002.000000\n",
+		"return_code=2\n",
+		"output=DISK WARNING - 91% used|'/'=91%;90;95\\n/ 91%\\n/var 10%\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("checkresult file: got '%s', want to contain '%s'", body, want)
+		}
+	}
+
+	if _, err := os.Stat(files[0] + ".ok"); err != nil {
+		t.Errorf("checkresult marker: error, got '%s', want 'nil'", err)
+	}
+}
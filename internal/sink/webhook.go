@@ -0,0 +1,200 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricWebhookQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "webhook_queue_depth",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of check results waiting to be sent to the webhook",
+	}, []string{"sink"})
+	metricWebhookDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "webhook_dropped_total",
+		Namespace: "rsca",
+		Subsystem: "sink",
+		Help:      "number of check results dropped because the webhook queue was full",
+	}, []string{"sink"})
+)
+
+var (
+	// ErrWebhookStatus is returned when the webhook responds with a non-2xx status.
+	ErrWebhookStatus = errors.New("unexpected webhook response status")
+
+	// ErrInvalidHeader is returned when a webhook header is not in the `Name: value` format.
+	ErrInvalidHeader = errors.New("invalid webhook header")
+)
+
+// Webhook is a Sink that POSTs each check result as JSON to a URL.
+//
+// Check results are queued and sent by a single routine, so a slow webhook does not block the
+// callers of Enqueue.
+type Webhook struct {
+	Logger  *slog.Logger
+	name    string
+	url     string
+	headers http.Header
+	client  *http.Client
+	timeout time.Duration
+	queue   chan queuedEvent
+}
+
+// queuedEvent is a check result waiting to be sent, done is called once it has been sent or could
+// not be sent.
+type queuedEvent struct {
+	msg  *api.EventMessage
+	done func(error)
+}
+
+// NewWebhook returns a Webhook sink, headers are in the `Name: value` format. Up to queueSize check
+// results are queued while waiting for the webhook.
+func NewWebhook(
+	logger *slog.Logger,
+	name, url string,
+	headers []string,
+	timeout time.Duration,
+	queueSize int,
+) (*Webhook, error) {
+	hdr := http.Header{}
+
+	for _, v := range headers {
+		name, value, ok := strings.Cut(v, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHeader, v)
+		}
+
+		hdr.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	return &Webhook{
+		Logger:  logger,
+		name:    name,
+		url:     url,
+		headers: hdr,
+		client:  &http.Client{Timeout: timeout},
+		timeout: timeout,
+		queue:   make(chan queuedEvent, max(queueSize, 1)),
+	}, nil
+}
+
+// Write sends the check result to the webhook and waits until it has been sent.
+func (s *Webhook) Write(ctx context.Context, msg *api.EventMessage) error {
+	return waitEnqueue(ctx, s, msg)
+}
+
+// Enqueue queues the check result to be sent to the webhook, done is called once it has been sent.
+// ErrQueueFull is returned when the queue is full.
+func (s *Webhook) Enqueue(_ context.Context, msg *api.EventMessage, done func(error)) error {
+	select {
+	case s.queue <- queuedEvent{msg: msg, done: done}:
+		metricWebhookQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+
+		return nil
+	default:
+		metricWebhookDropped.WithLabelValues(s.name).Inc()
+
+		return ErrQueueFull
+	}
+}
+
+// Run is a routine that sends the queued check results to the webhook until the context is
+// cancelled, the check results still queued are then sent before it returns.
+func (s *Webhook) Run(ctx context.Context) func() error {
+	return func() error {
+		// requests are sent until the timeout after the context is cancelled, so the check results
+		// still queued at shutdown are sent instead of failing.
+		sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+
+		stop := context.AfterFunc(ctx, func() { time.AfterFunc(s.timeout, cancel) })
+		defer stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.drain(sendCtx)
+
+				return nil
+			case ev := <-s.queue:
+				s.send(sendCtx, ev)
+			}
+		}
+	}
+}
+
+// drain sends the check results still queued, check results that can not be sent before the
+// context is cancelled fail so they are not acknowledged.
+func (s *Webhook) drain(ctx context.Context) {
+	for {
+		select {
+		case ev := <-s.queue:
+			s.send(ctx, ev)
+		default:
+			return
+		}
+	}
+}
+
+// send posts a queued check result and calls its done function.
+func (s *Webhook) send(ctx context.Context, ev queuedEvent) {
+	metricWebhookQueueDepth.WithLabelValues(s.name).Set(float64(len(s.queue)))
+
+	err := s.post(ctx, ev.msg)
+	if err != nil {
+		s.Logger.DebugContext(ctx, "unable to send check result to webhook",
+			slog.String("sink", s.name), slogtool.ErrorAttr(err),
+		)
+	}
+
+	if ev.done != nil {
+		ev.done(err)
+	}
+}
+
+// post sends the check result to the webhook.
+func (s *Webhook) post(ctx context.Context, msg *api.EventMessage) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal check result: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to create webhook request: %w", err)
+	}
+
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", ErrWebhookStatus, resp.Status)
+	}
+
+	return nil
+}
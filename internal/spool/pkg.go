@@ -0,0 +1,3 @@
+// Package spool contains a bounded on-disk queue for check results that have not yet
+// been delivered to the server.
+package spool
@@ -0,0 +1,238 @@
+package spool
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/na4ma4/go-permbits"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// entrySuffix is the file extension of a completed spool entry.
+	entrySuffix = ".evt"
+
+	// tempPrefix is the prefix of spool entries that are still being written.
+	tempPrefix = ".tmp-"
+)
+
+// Spool is a bounded, persistent, ordered queue of api.EventMessage records.
+type Spool struct {
+	Logger  *slog.Logger
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+	seq     uint64
+	lock    sync.Mutex
+}
+
+type entry struct {
+	name string
+	ts   time.Time
+	size int64
+}
+
+// New returns a Spool stored in path, limited to maxSize bytes and entries no older than maxAge.
+//
+// A maxSize or maxAge of zero disables that limit.
+func New(logger *slog.Logger, path string, maxSize int64, maxAge time.Duration) (*Spool, error) {
+	if err := os.MkdirAll(path, permbits.UserAll); err != nil {
+		return nil, fmt.Errorf("unable to create spool directory: %w", err)
+	}
+
+	s := &Spool{
+		Logger:  logger,
+		path:    path,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+	}
+
+	// remove any entries that were only partially written before a crash.
+	if tmps, err := filepath.Glob(filepath.Join(path, tempPrefix+"*")); err == nil {
+		for _, tmp := range tmps {
+			_ = os.Remove(tmp)
+		}
+	}
+
+	return s, nil
+}
+
+// Put adds a message to the end of the spool, the oldest entries are discarded if the
+// spool would grow beyond its maximum size.
+func (s *Spool) Put(msg *api.EventMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to encode message: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err = s.prune(int64(len(data))); err != nil {
+		return err
+	}
+
+	s.seq++
+	name := fmt.Sprintf("%020d-%08d%s", time.Now().UnixNano(), s.seq%100000000, entrySuffix)
+	tmpName := filepath.Join(s.path, tempPrefix+name)
+
+	if err = os.WriteFile(tmpName, data, permbits.UserRead+permbits.UserWrite); err != nil {
+		_ = os.Remove(tmpName)
+
+		return fmt.Errorf("unable to write spool entry: %w", err)
+	}
+
+	if err = os.Rename(tmpName, filepath.Join(s.path, name)); err != nil {
+		_ = os.Remove(tmpName)
+
+		return fmt.Errorf("unable to commit spool entry: %w", err)
+	}
+
+	return nil
+}
+
+// Walk calls walkFunc for each message in the spool from oldest to newest, entries are removed
+// from the spool when walkFunc returns nil, walking stops at the first error which is returned.
+func (s *Spool) Walk(walkFunc func(*api.EventMessage) error) error {
+	s.lock.Lock()
+	if err := s.prune(0); err != nil {
+		s.lock.Unlock()
+
+		return err
+	}
+
+	entries, err := s.entries()
+	s.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		fileName := filepath.Join(s.path, e.name)
+
+		data, readErr := os.ReadFile(fileName)
+		if errors.Is(readErr, fs.ErrNotExist) {
+			// pruned since it was listed.
+			continue
+		} else if readErr != nil {
+			return fmt.Errorf("unable to read spool entry: %w", readErr)
+		}
+
+		msg := &api.EventMessage{}
+		if decodeErr := proto.Unmarshal(data, msg); decodeErr != nil {
+			s.Logger.Warn("discarding corrupt spool entry",
+				slog.String("spool.entry", fileName),
+				slogtool.ErrorAttr(decodeErr),
+			)
+
+			_ = os.Remove(fileName)
+
+			continue
+		}
+
+		if err = walkFunc(msg); err != nil {
+			return err
+		}
+
+		if err = os.Remove(fileName); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to remove spool entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of messages in the spool.
+func (s *Spool) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, _ := s.entries()
+
+	return len(entries)
+}
+
+// entries returns the completed spool entries sorted oldest first.
+func (s *Spool) entries() ([]entry, error) {
+	des, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spool directory: %w", err)
+	}
+
+	out := make([]entry, 0, len(des))
+
+	for _, de := range des {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), entrySuffix) {
+			continue
+		}
+
+		tsText, _, _ := strings.Cut(de.Name(), "-")
+
+		nanos, parseErr := strconv.ParseInt(tsText, 10, 64)
+		if parseErr != nil {
+			continue
+		}
+
+		e := entry{name: de.Name(), ts: time.Unix(0, nanos)}
+		if fi, infoErr := de.Info(); infoErr == nil {
+			e.size = fi.Size()
+		}
+
+		out = append(out, e)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+
+	return out, nil
+}
+
+// prune removes entries older than the maximum age and the oldest entries until there is
+// room for an additional entry of the supplied size.
+func (s *Spool) prune(additional int64) error {
+	entries, err := s.entries()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+
+	expireTime := time.Now().Add(-1 * s.maxAge)
+
+	for _, e := range entries {
+		expired := s.maxAge > 0 && e.ts.Before(expireTime)
+		oversize := s.maxSize > 0 && total+additional > s.maxSize
+
+		if !expired && !oversize {
+			break
+		}
+
+		s.Logger.Warn("discarding spooled check result",
+			slog.String("spool.entry", e.name),
+			slog.Bool("spool.expired", expired),
+			slog.Bool("spool.full", oversize),
+		)
+
+		if err = os.Remove(filepath.Join(s.path, e.name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to remove spool entry: %w", err)
+		}
+
+		total -= e.size
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package spool_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/spool"
+	"google.golang.org/protobuf/proto"
+)
+
+func testLogger() *slog.Logger {
+	var buf bytes.Buffer
+
+	return slog.New(slog.NewJSONHandler(&buf, nil))
+}
+
+func eventMessage(check string) *api.EventMessage {
+	return api.EventMessage_builder{
+		Hostname: proto.String("localhost.localdomain"),
+		Check:    proto.String(check),
+		Output:   proto.String("Test All OK"),
+	}.Build()
+}
+
+func walkChecks(t *testing.T, sp *spool.Spool) []string {
+	t.Helper()
+
+	out := []string{}
+
+	if err := sp.Walk(func(in *api.EventMessage) error {
+		out = append(out, in.GetCheck())
+
+		return nil
+	}); err != nil {
+		t.Errorf("spool.Walk(): error, got '%s', want 'nil'", err)
+	}
+
+	return out
+}
+
+func TestSpoolOrder(t *testing.T) {
+	sp, err := spool.New(testLogger(), t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("spool.New(): error, got '%s', want 'nil'", err)
+	}
+
+	for _, check := range []string{"ONE", "TWO", "THREE"} {
+		if err = sp.Put(eventMessage(check)); err != nil {
+			t.Fatalf("spool.Put(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	if diff := cmp.Diff(walkChecks(t, sp), []string{"ONE", "TWO", "THREE"}); diff != "" {
+		t.Errorf("spool.Walk(): checks -got +want:\n%s", diff)
+	}
+
+	if v := sp.Len(); v != 0 {
+		t.Errorf("spool.Len(): got '%d', want '0'", v)
+	}
+}
+
+func TestSpoolWalkStopsOnError(t *testing.T) {
+	sp, err := spool.New(testLogger(), t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("spool.New(): error, got '%s', want 'nil'", err)
+	}
+
+	_ = sp.Put(eventMessage("ONE"))
+	_ = sp.Put(eventMessage("TWO"))
+
+	errSend := errors.New("send failed")
+
+	if err = sp.Walk(func(*api.EventMessage) error { return errSend }); !errors.Is(err, errSend) {
+		t.Errorf("spool.Walk(): error, got '%v', want '%s'", err, errSend)
+	}
+
+	if diff := cmp.Diff(walkChecks(t, sp), []string{"ONE", "TWO"}); diff != "" {
+		t.Errorf("spool.Walk(): checks -got +want:\n%s", diff)
+	}
+}
+
+func TestSpoolMaxSize(t *testing.T) {
+	msgSize := proto.Size(eventMessage("ONE"))
+
+	sp, err := spool.New(testLogger(), t.TempDir(), int64(msgSize*2), 0)
+	if err != nil {
+		t.Fatalf("spool.New(): error, got '%s', want 'nil'", err)
+	}
+
+	for _, check := range []string{"ONE", "TWO", "THR"} {
+		_ = sp.Put(eventMessage(check))
+	}
+
+	if diff := cmp.Diff(walkChecks(t, sp), []string{"TWO", "THR"}); diff != "" {
+		t.Errorf("spool.Walk(): checks -got +want:\n%s", diff)
+	}
+}
+
+func TestSpoolMaxAge(t *testing.T) {
+	sp, err := spool.New(testLogger(), t.TempDir(), 0, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("spool.New(): error, got '%s', want 'nil'", err)
+	}
+
+	_ = sp.Put(eventMessage("ONE"))
+
+	time.Sleep(100 * time.Millisecond)
+
+	_ = sp.Put(eventMessage("TWO"))
+
+	if diff := cmp.Diff(walkChecks(t, sp), []string{"TWO"}); diff != "" {
+		t.Errorf("spool.Walk(): checks -got +want:\n%s", diff)
+	}
+}
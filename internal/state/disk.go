@@ -1,11 +1,15 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
 	"github.com/na4ma4/rsca/api"
 )
 
@@ -159,6 +163,64 @@ func (d *Disk) Delete(in *api.Member) error {
 		}
 	}
 
+	var rs []Result
+
+	if lookupErr := d.db.Find("Hostname", strings.ToLower(in.GetName()), &rs); lookupErr == nil {
+		for _, r := range rs {
+			if deleteErr := d.db.DeleteStruct(&r); deleteErr != nil {
+				return fmt.Errorf("unable to delete result: %w", deleteErr)
+			}
+		}
+	}
+
+	var hs []History
+
+	if lookupErr := d.db.Find("Hostname", strings.ToLower(in.GetName()), &hs); lookupErr == nil {
+		for _, h := range hs {
+			if deleteErr := d.db.DeleteStruct(&h); deleteErr != nil {
+				return fmt.Errorf("unable to delete history: %w", deleteErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetResult stores a check result as the latest result of the host and check.
+func (d *Disk) SetResult(msg *api.EventMessage) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	r := Result{
+		ID:       ResultID(msg.GetHostname(), msg.GetCheck()),
+		Hostname: strings.ToLower(msg.GetHostname()),
+		Event:    msg,
+	}
+
+	if err := d.db.Save(&r); err != nil {
+		return fmt.Errorf("unable to save result: %w", err)
+	}
+
+	return nil
+}
+
+// WalkResults will run a supplied function over the latest result of each host and check.
+func (d *Disk) WalkResults(walkFunc func(*api.EventMessage) error) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var rs []Result
+
+	if err := d.db.All(&rs); err != nil {
+		return fmt.Errorf("unable to retrieve results: %w", err)
+	}
+
+	for _, v := range rs {
+		if err := walkFunc(v.Event); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -213,3 +275,88 @@ func (d *Disk) DeactivateByHostname(hostname string) error {
 
 	return nil
 }
+
+// AddHistory stores a check result in the history of the host and check, the oldest results are
+// removed to keep at most maxCount results (0 is unlimited).
+func (d *Disk) AddHistory(msg *api.EventMessage, maxCount int) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	h := newHistory(msg)
+
+	if err := d.db.Save(h); err != nil {
+		return fmt.Errorf("unable to save history: %w", err)
+	}
+
+	if maxCount <= 0 {
+		return nil
+	}
+
+	var hs []History
+
+	if err := d.db.Select(q.Eq("ResultID", h.ResultID)).OrderBy("Timestamp", "ID").Find(&hs); err != nil {
+		return fmt.Errorf("unable to retrieve history: %w", err)
+	}
+
+	for i := 0; i < len(hs)-maxCount; i++ {
+		if err := d.db.DeleteStruct(&hs[i]); err != nil {
+			return fmt.Errorf("unable to delete history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetHistory returns the results of the host and check that were run between since and until
+// (a zero time is unbounded), oldest first.
+func (d *Disk) GetHistory(hostName, check string, since, until time.Time) ([]*api.EventMessage, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	matchers := []q.Matcher{q.Eq("ResultID", ResultID(hostName, check))}
+
+	if !since.IsZero() {
+		matchers = append(matchers, q.Gte("Timestamp", since.UTC()))
+	}
+
+	if !until.IsZero() {
+		matchers = append(matchers, q.Lte("Timestamp", until.UTC()))
+	}
+
+	var hs []History
+
+	if err := d.db.Select(matchers...).OrderBy("Timestamp", "ID").Find(&hs); err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return nil, fmt.Errorf("unable to retrieve history: %w", err)
+	}
+
+	out := make([]*api.EventMessage, 0, len(hs))
+	for _, h := range hs {
+		out = append(out, h.Event)
+	}
+
+	return out, nil
+}
+
+// PruneHistory removes the results that were run before the supplied time.
+func (d *Disk) PruneHistory(before time.Time) (int, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var hs []History
+
+	if err := d.db.Select(q.Lt("Timestamp", before.UTC())).Find(&hs); err != nil {
+		if errors.Is(err, storm.ErrNotFound) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("unable to retrieve history: %w", err)
+	}
+
+	for _, h := range hs {
+		if err := d.db.DeleteStruct(&h); err != nil {
+			return 0, fmt.Errorf("unable to delete history: %w", err)
+		}
+	}
+
+	return len(hs), nil
+}
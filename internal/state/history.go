@@ -0,0 +1,95 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// History stores a historic check result of a host and check with annotations that are compatible
+// with asdine/storm.
+type History struct {
+	ID        int       `storm:"id,increment"`
+	ResultID  string    `storm:"index"`
+	Hostname  string    `storm:"index"`
+	Timestamp time.Time `storm:"index"`
+	Event     *api.EventMessage
+}
+
+// historyRecord is the stored form of History, api.EventMessage only has unexported fields so it is
+// encoded with protojson.
+type historyRecord struct {
+	ID        int
+	ResultID  string
+	Hostname  string
+	Timestamp time.Time
+	Event     json.RawMessage
+}
+
+// newHistory returns a History record for the check result, the timestamp is the time the check was run.
+func newHistory(msg *api.EventMessage) *History {
+	ts := time.Now()
+	if msg.HasRequestTimestamp() {
+		ts = msg.GetRequestTimestamp().AsTime()
+	}
+
+	return &History{
+		ResultID:  ResultID(msg.GetHostname(), msg.GetCheck()),
+		Hostname:  strings.ToLower(msg.GetHostname()),
+		Timestamp: ts.UTC(),
+		Event:     msg,
+	}
+}
+
+// MarshalJSON encodes the history record for storage.
+func (h History) MarshalJSON() ([]byte, error) {
+	rec := historyRecord{
+		ID:        h.ID,
+		ResultID:  h.ResultID,
+		Hostname:  h.Hostname,
+		Timestamp: h.Timestamp,
+	}
+
+	if h.Event != nil {
+		data, err := protojson.Marshal(h.Event)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode history: %w", err)
+		}
+
+		rec.Event = data
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode history record: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON decodes a stored history record.
+func (h *History) UnmarshalJSON(data []byte) error {
+	var rec historyRecord
+
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fmt.Errorf("unable to decode history record: %w", err)
+	}
+
+	h.ID = rec.ID
+	h.ResultID = rec.ResultID
+	h.Hostname = rec.Hostname
+	h.Timestamp = rec.Timestamp
+	h.Event = &api.EventMessage{}
+
+	if len(rec.Event) > 0 {
+		if err := protojson.Unmarshal(rec.Event, h.Event); err != nil {
+			return fmt.Errorf("unable to decode history: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package state_test
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/state"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testHistory(hostname, check, output string, ts time.Time) *api.EventMessage {
+	return api.EventMessage_builder{
+		Hostname:         proto.String(hostname),
+		Type:             api.CheckType_SERVICE.Enum(),
+		Check:            proto.String(check),
+		Status:           api.Status_OK.Enum(),
+		Output:           proto.String(output),
+		RequestTimestamp: timestamppb.New(ts),
+	}.Build()
+}
+
+func historyOutputs(t *testing.T, st state.State, hostname, check string, since, until time.Time) []string {
+	t.Helper()
+
+	events, err := st.GetHistory(hostname, check, since, until)
+	if err != nil {
+		t.Fatalf("Disk.GetHistory(): error, got '%s', want 'nil'", err)
+	}
+
+	got := []string{}
+	for _, msg := range events {
+		got = append(got, msg.GetOutput())
+	}
+
+	return got
+}
+
+func TestDiskHistory(t *testing.T) {
+	t.Parallel()
+
+	st, err := state.NewDiskState(slog.New(slog.NewJSONHandler(io.Discard, nil)), filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("state.NewDiskState(): error, got '%s', want 'nil'", err)
+	}
+
+	defer st.Close()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, output := range []string{"one", "two", "three", "four"} {
+		if err = st.AddHistory(testHistory("web01", "DISK", output, base.Add(time.Duration(i)*time.Hour)), 3); err != nil {
+			t.Fatalf("Disk.AddHistory(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	if err = st.AddHistory(testHistory("web01", "LOAD", "load", base), 3); err != nil {
+		t.Fatalf("Disk.AddHistory(): error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff(
+		[]string{"two", "three", "four"},
+		historyOutputs(t, st, "WEB01", "disk", time.Time{}, time.Time{}),
+	); diff != "" {
+		t.Errorf("Disk.GetHistory(): max count -want +got:\n%s", diff)
+	}
+
+	if diff := cmp.Diff(
+		[]string{"three"},
+		historyOutputs(t, st, "web01", "DISK", base.Add(2*time.Hour), base.Add(150*time.Minute)),
+	); diff != "" {
+		t.Errorf("Disk.GetHistory(): range -want +got:\n%s", diff)
+	}
+
+	n, err := st.PruneHistory(base.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("Disk.PruneHistory(): error, got '%s', want 'nil'", err)
+	}
+
+	if n != 2 {
+		t.Errorf("Disk.PruneHistory(): got '%d', want '%d'", n, 2)
+	}
+
+	if diff := cmp.Diff(
+		[]string{"three", "four"},
+		historyOutputs(t, st, "web01", "DISK", time.Time{}, time.Time{}),
+	); diff != "" {
+		t.Errorf("Disk.GetHistory(): after prune -want +got:\n%s", diff)
+	}
+
+	_ = st.AddWithStreamID("stream", api.Member_builder{Name: proto.String("web01")}.Build())
+
+	if err = st.Delete(api.Member_builder{Name: proto.String("web01")}.Build()); err != nil {
+		t.Fatalf("Disk.Delete(): error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff(
+		[]string{},
+		historyOutputs(t, st, "web01", "DISK", time.Time{}, time.Time{}),
+	); diff != "" {
+		t.Errorf("Disk.GetHistory(): after delete -want +got:\n%s", diff)
+	}
+}
@@ -1,6 +1,12 @@
 package state
 
-import "github.com/na4ma4/rsca/api"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
 
 // Member stores a member detail record with annoations that are compatible with asdine/storm.
 type Member struct {
@@ -9,6 +15,59 @@ type Member struct {
 	Member   *api.Member
 }
 
+// memberRecord is the stored form of Member, api.Member only has unexported fields so it is
+// encoded with protojson.
+type memberRecord struct {
+	ID       string
+	StreamID string
+	Member   json.RawMessage
+}
+
+// MarshalJSON encodes the member record for storage.
+func (m Member) MarshalJSON() ([]byte, error) {
+	rec := memberRecord{
+		ID:       m.ID,
+		StreamID: m.StreamID,
+	}
+
+	if m.Member != nil {
+		data, err := protojson.Marshal(m.Member)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode member: %w", err)
+		}
+
+		rec.Member = data
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode member record: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON decodes a stored member record.
+func (m *Member) UnmarshalJSON(data []byte) error {
+	var rec memberRecord
+
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fmt.Errorf("unable to decode member record: %w", err)
+	}
+
+	m.ID = rec.ID
+	m.StreamID = rec.StreamID
+	m.Member = &api.Member{}
+
+	if len(rec.Member) > 0 {
+		if err := protojson.Unmarshal(rec.Member, m.Member); err != nil {
+			return fmt.Errorf("unable to decode member: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // func apiMemberToMember(m *api.Member) *Member {
 // 	return &Member{
 // 		ID:     m.GetName(),
@@ -0,0 +1,76 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Result stores the latest check result of a host and check with annotations that are compatible
+// with asdine/storm.
+type Result struct {
+	ID       string `storm:"id"`
+	Hostname string `storm:"index"`
+	Event    *api.EventMessage
+}
+
+// resultRecord is the stored form of Result, api.EventMessage only has unexported fields so it is
+// encoded with protojson.
+type resultRecord struct {
+	ID       string
+	Hostname string
+	Event    json.RawMessage
+}
+
+// ResultID returns the ID of the result for a host and check, hostnames and checks are not case sensitive.
+func ResultID(hostname, check string) string {
+	return strings.ToLower(hostname) + "/" + strings.ToLower(check)
+}
+
+// MarshalJSON encodes the result record for storage.
+func (r Result) MarshalJSON() ([]byte, error) {
+	rec := resultRecord{
+		ID:       r.ID,
+		Hostname: r.Hostname,
+	}
+
+	if r.Event != nil {
+		data, err := protojson.Marshal(r.Event)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode result: %w", err)
+		}
+
+		rec.Event = data
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode result record: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON decodes a stored result record.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var rec resultRecord
+
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fmt.Errorf("unable to decode result record: %w", err)
+	}
+
+	r.ID = rec.ID
+	r.Hostname = rec.Hostname
+	r.Event = &api.EventMessage{}
+
+	if len(rec.Event) > 0 {
+		if err := protojson.Unmarshal(rec.Event, r.Event); err != nil {
+			return fmt.Errorf("unable to decode result: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package state_test
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/state"
+	"google.golang.org/protobuf/proto"
+)
+
+func testResult(hostname, check string, status api.Status) *api.EventMessage {
+	return api.EventMessage_builder{
+		Hostname: proto.String(hostname),
+		Type:     api.CheckType_SERVICE.Enum(),
+		Check:    proto.String(check),
+		Status:   &status,
+		Output:   proto.String(check + " " + status.String()),
+	}.Build()
+}
+
+func TestDiskResults(t *testing.T) {
+	t.Parallel()
+
+	st, err := state.NewDiskState(slog.New(slog.NewJSONHandler(io.Discard, nil)), filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("state.NewDiskState(): error, got '%s', want 'nil'", err)
+	}
+
+	defer st.Close()
+
+	for _, msg := range []*api.EventMessage{
+		testResult("web01", "DISK", api.Status_OK),
+		testResult("web01", "LOAD", api.Status_OK),
+		testResult("web02", "DISK", api.Status_OK),
+		testResult("WEB01", "disk", api.Status_CRITICAL),
+	} {
+		if err = st.SetResult(msg); err != nil {
+			t.Fatalf("Disk.SetResult(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	_ = st.AddWithStreamID("stream", api.Member_builder{Name: proto.String("web01")}.Build())
+
+	if err = st.Delete(api.Member_builder{Name: proto.String("web01")}.Build()); err != nil {
+		t.Fatalf("Disk.Delete(): error, got '%s', want 'nil'", err)
+	}
+
+	got := []string{}
+
+	if err = st.WalkResults(func(msg *api.EventMessage) error {
+		got = append(got, msg.GetHostname()+" "+msg.GetOutput())
+
+		return nil
+	}); err != nil {
+		t.Fatalf("Disk.WalkResults(): error, got '%s', want 'nil'", err)
+	}
+
+	if diff := cmp.Diff([]string{"web02 DISK OK"}, got); diff != "" {
+		t.Errorf("Disk.WalkResults(): -want +got:\n%s", diff)
+	}
+
+	if err = st.SetResult(testResult("web02", "DISK", api.Status_WARNING)); err != nil {
+		t.Fatalf("Disk.SetResult(): error, got '%s', want 'nil'", err)
+	}
+
+	got = []string{}
+	_ = st.WalkResults(func(msg *api.EventMessage) error {
+		got = append(got, msg.GetHostname()+" "+msg.GetOutput())
+
+		return nil
+	})
+
+	if diff := cmp.Diff([]string{"web02 DISK WARNING"}, got); diff != "" {
+		t.Errorf("Disk.WalkResults(): -want +got:\n%s", diff)
+	}
+}
@@ -1,6 +1,10 @@
 package state
 
-import "github.com/na4ma4/rsca/api"
+import (
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+)
 
 // State is an interface for a service that can store and list the active and historic members.
 type State interface {
@@ -20,6 +24,18 @@ type State interface {
 	GetStreamIDByMember(member *api.Member) (string, bool)
 	// Delete removes a member and will disconnect them if they're connected.
 	Delete(member *api.Member) error
+	// SetResult stores a check result as the latest result of the host and check.
+	SetResult(msg *api.EventMessage) error
+	// WalkResults will run a supplied function over the latest result of each host and check.
+	WalkResults(walkFunc func(*api.EventMessage) error) error
+	// AddHistory stores a check result in the history of the host and check, the oldest results are
+	// removed to keep at most maxCount results (0 is unlimited).
+	AddHistory(msg *api.EventMessage, maxCount int) error
+	// GetHistory returns the results of the host and check that were run between since and until
+	// (a zero time is unbounded), oldest first.
+	GetHistory(hostName, check string, since, until time.Time) ([]*api.EventMessage, error)
+	// PruneHistory removes the results that were run before the supplied time.
+	PruneHistory(before time.Time) (int, error)
 
 	// Add(*api.Member) error
 	// Deactivate(*api.Member) error
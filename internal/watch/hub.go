@@ -0,0 +1,178 @@
+package watch
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultBufferSize is the number of events buffered for each subscriber.
+const DefaultBufferSize = 256
+
+//nolint:gochecknoglobals // metrics are registered once per process.
+var (
+	metricSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:      "subscribers",
+		Namespace: "rsca",
+		Subsystem: "watch",
+		Help:      "number of active watch subscribers",
+	})
+	metricDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name:      "events_dropped_total",
+		Namespace: "rsca",
+		Subsystem: "watch",
+		Help:      "number of events not sent to watch subscribers that were not keeping up",
+	})
+)
+
+// Filter limits the events that are sent to a subscriber.
+type Filter struct {
+	// Hosts are hostname patterns (eg. `web*`), an empty list matches all hosts.
+	Hosts []string
+
+	// Tags match events from hosts with any of the tags, an empty list matches all hosts.
+	Tags []string
+
+	// Checks match check results of the checks, lifecycle events are not matched when set.
+	Checks []string
+
+	// Statuses match check results with the statuses, lifecycle events are not matched when set.
+	Statuses []api.Status
+}
+
+// FilterFromRequest returns the Filter of a watch request.
+func FilterFromRequest(in *api.WatchRequest) Filter {
+	return Filter{
+		Hosts:    in.GetHostnames(),
+		Tags:     in.GetTags(),
+		Checks:   in.GetChecks(),
+		Statuses: in.GetStatuses(),
+	}
+}
+
+// Match returns true if the event from a host with the supplied tags passes the filter.
+func (f Filter) Match(ev *api.WatchEvent, tags []string) bool {
+	if len(f.Hosts) > 0 && !slices.ContainsFunc(f.Hosts, func(pattern string) bool {
+		ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(ev.GetHostname()))
+
+		return ok
+	}) {
+		return false
+	}
+
+	if len(f.Tags) > 0 && !slices.ContainsFunc(f.Tags, func(tag string) bool {
+		return slices.ContainsFunc(tags, func(v string) bool { return strings.EqualFold(v, tag) })
+	}) {
+		return false
+	}
+
+	if len(f.Checks) == 0 && len(f.Statuses) == 0 {
+		return true
+	}
+
+	if ev.GetType() != api.WatchEventType_RESULT {
+		return false
+	}
+
+	if len(f.Checks) > 0 && !slices.ContainsFunc(f.Checks, func(v string) bool {
+		return strings.EqualFold(v, ev.GetEvent().GetCheck())
+	}) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, ev.GetEvent().GetStatus()) {
+		return false
+	}
+
+	return true
+}
+
+// Subscription receives the events that match its filter.
+type Subscription struct {
+	filter  Filter
+	events  chan *api.WatchEvent
+	dropped atomic.Uint64
+}
+
+// Events returns the channel the events are sent on.
+func (s *Subscription) Events() <-chan *api.WatchEvent {
+	return s.events
+}
+
+// Dropped returns and resets the number of events that were dropped because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+// Hub sends published events to the subscribers, events are dropped for subscribers that are not
+// keeping up so publishing never blocks.
+type Hub struct {
+	lock       sync.RWMutex
+	bufferSize int
+	subs       map[*Subscription]struct{}
+}
+
+// NewHub returns a Hub that buffers bufferSize events for each subscriber.
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &Hub{
+		bufferSize: bufferSize,
+		subs:       map[*Subscription]struct{}{},
+	}
+}
+
+// Subscribe returns a Subscription for the events that match the filter, it must be removed with
+// Unsubscribe.
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan *api.WatchEvent, h.bufferSize),
+	}
+
+	h.lock.Lock()
+	h.subs[sub] = struct{}{}
+	h.lock.Unlock()
+
+	metricSubscribers.Inc()
+
+	return sub
+}
+
+// Unsubscribe removes the Subscription, no more events are sent to it.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		metricSubscribers.Dec()
+	}
+}
+
+// Publish sends the event from a host with the supplied tags to the matching subscribers.
+func (h *Hub) Publish(ev *api.WatchEvent, tags []string) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for sub := range h.subs {
+		if !sub.filter.Match(ev, tags) {
+			continue
+		}
+
+		select {
+		case sub.events <- ev:
+		default:
+			sub.dropped.Add(1)
+			metricDropped.Inc()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package watch_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/watch"
+	"google.golang.org/protobuf/proto"
+)
+
+func testResult(hostname, check string, st api.Status) *api.WatchEvent {
+	return api.WatchEvent_builder{
+		Type:     api.WatchEventType_RESULT.Enum(),
+		Hostname: proto.String(hostname),
+		Event: api.EventMessage_builder{
+			Hostname: proto.String(hostname),
+			Check:    proto.String(check),
+			Status:   &st,
+		}.Build(),
+	}.Build()
+}
+
+func testHost(eventType api.WatchEventType, hostname string) *api.WatchEvent {
+	return api.WatchEvent_builder{
+		Type:     &eventType,
+		Hostname: proto.String(hostname),
+	}.Build()
+}
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter watch.Filter
+		event  *api.WatchEvent
+		tags   []string
+		want   bool
+	}{
+		{"empty", watch.Filter{}, testHost(api.WatchEventType_REGISTER, "web01"), nil, true},
+		{"host pattern", watch.Filter{Hosts: []string{"WEB*"}}, testResult("web01", "DISK", api.Status_OK), nil, true},
+		{"host mismatch", watch.Filter{Hosts: []string{"db*"}}, testResult("web01", "DISK", api.Status_OK), nil, false},
+		{
+			"tag", watch.Filter{Tags: []string{"prod"}},
+			testHost(api.WatchEventType_REMOVED, "web01"), []string{"PROD"}, true,
+		},
+		{"tag mismatch", watch.Filter{Tags: []string{"prod"}}, testResult("web01", "DISK", api.Status_OK), []string{"dev"}, false},
+		{"check", watch.Filter{Checks: []string{"disk"}}, testResult("web01", "DISK", api.Status_OK), nil, true},
+		{"check mismatch", watch.Filter{Checks: []string{"load"}}, testResult("web01", "DISK", api.Status_OK), nil, false},
+		{
+			"status", watch.Filter{Statuses: []api.Status{api.Status_CRITICAL}},
+			testResult("web01", "DISK", api.Status_CRITICAL), nil, true,
+		},
+		{
+			"status mismatch", watch.Filter{Statuses: []api.Status{api.Status_CRITICAL}},
+			testResult("web01", "DISK", api.Status_OK), nil, false,
+		},
+		{
+			"lifecycle with check", watch.Filter{Checks: []string{"disk"}},
+			testHost(api.WatchEventType_DISCONNECT, "web01"), nil, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.filter.Match(tt.event, tt.tags); got != tt.want {
+				t.Errorf("Filter.Match(): got '%t', want '%t'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHubPublishDropsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	hub := watch.NewHub(2)
+
+	all := hub.Subscribe(watch.Filter{})
+	web := hub.Subscribe(watch.Filter{Hosts: []string{"web*"}})
+
+	for _, hostname := range []string{"web01", "db01", "web02", "web03"} {
+		hub.Publish(testHost(api.WatchEventType_UPDATE, hostname), nil)
+	}
+
+	hub.Unsubscribe(web)
+	hub.Publish(testHost(api.WatchEventType_UPDATE, "web04"), nil)
+
+	received := func(sub *watch.Subscription) []string {
+		got := []string{}
+
+		for {
+			select {
+			case ev := <-sub.Events():
+				got = append(got, ev.GetHostname())
+			default:
+				return got
+			}
+		}
+	}
+
+	if diff := cmp.Diff([]string{"web01", "db01"}, received(all)); diff != "" {
+		t.Errorf("Hub.Publish(): all -want +got:\n%s", diff)
+	}
+
+	if got, want := all.Dropped(), uint64(3); got != want {
+		t.Errorf("Subscription.Dropped(): all got '%d', want '%d'", got, want)
+	}
+
+	if diff := cmp.Diff([]string{"web01", "web02"}, received(web)); diff != "" {
+		t.Errorf("Hub.Publish(): web -want +got:\n%s", diff)
+	}
+
+	if got, want := web.Dropped(), uint64(1); got != want {
+		t.Errorf("Subscription.Dropped(): web got '%d', want '%d'", got, want)
+	}
+
+	if got := all.Dropped(); got != 0 {
+		t.Errorf("Subscription.Dropped(): after reset got '%d', want '0'", got)
+	}
+}
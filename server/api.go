@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -14,8 +15,13 @@ import (
 	"github.com/na4ma4/config"
 	"github.com/na4ma4/go-slogtool"
 	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/audit"
+	"github.com/na4ma4/rsca/internal/freshness"
 	"github.com/na4ma4/rsca/internal/helpers"
+	"github.com/na4ma4/rsca/internal/identity"
+	"github.com/na4ma4/rsca/internal/sink"
 	"github.com/na4ma4/rsca/internal/state"
+	"github.com/na4ma4/rsca/internal/watch"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/multierr"
@@ -29,14 +35,41 @@ import (
 // ssmChannelSize is the size of the buffered channel for serverStreamMessage.
 const ssmChannelSize = 2
 
+// ackChannelSize is the size of the buffered channel for the acknowledgements waiting to be sent to
+// a client, acknowledgements are dropped when it is full and the client resends the results.
+const ackChannelSize = 1000
+
 // Server is a api.RSCAServer for co-ordinating streams from clients.
 type Server struct {
 	Logger   *slog.Logger
 	hostname string
 	state    state.State
+	sinks    *sink.Chain
 	streams  map[string]*serverStream
 	lock     sync.Mutex
 	metric   *metric
+
+	// replies are the requests waiting for a reply from a client, by message id.
+	replies   map[string]chan runCheckReply
+	replyLock sync.Mutex
+
+	// freshness is the time of the last result of each service.
+	freshness *freshness.Tracker
+
+	// hostStatus is the hosts reported as down when the agent disconnects.
+	hostStatus *hostStatus
+
+	// history is the retention of the stored check result history.
+	history *resultHistory
+
+	// watch sends check results and host lifecycle events to the Watch subscribers.
+	watch *watch.Hub
+
+	// identity binds the hostnames used by clients to their certificate, nil if it is disabled.
+	identity *identity.Policy
+
+	// audit is the audit log of administrative requests, nil if it is disabled.
+	audit *audit.Log
 }
 
 type metric struct {
@@ -48,12 +81,21 @@ type metric struct {
 	PingMessageErrors   prometheus.Counter
 	EventStatus         *prometheus.CounterVec
 	PingLatency         *prometheus.GaugeVec
+	EventAckErrors      prometheus.Counter
+	StaleServices       prometheus.Counter
+	IdentityViolations  *prometheus.CounterVec
 }
 
 type serverStream struct {
 	Stream       api.RSCA_PipeServer
 	TriggerClose context.CancelFunc
 	Record       *api.Member
+
+	// acks are the acknowledgements of the results that have been written by the sinks.
+	acks chan *api.Message
+
+	// Identity is the client certificate identity of the stream, nil if the client did not present one.
+	Identity *identity.Identity
 }
 
 type serverStreamMessage struct {
@@ -62,11 +104,18 @@ type serverStreamMessage struct {
 }
 
 // NewServer returns a prepared server object.
-func NewServer(logger *slog.Logger, st state.State) *Server {
+func NewServer(logger *slog.Logger, st state.State, sinks *sink.Chain) *Server {
 	return &Server{
 		Logger:  logger,
 		streams: map[string]*serverStream{},
 		state:   st,
+		sinks:   sinks,
+		replies: map[string]chan runCheckReply{},
+
+		freshness:  freshness.NewTracker(),
+		hostStatus: newHostStatus(),
+		history:    &resultHistory{},
+		watch:      watch.NewHub(watch.DefaultBufferSize),
 		metric: &metric{
 			ActiveConnections: promauto.NewGauge(prometheus.GaugeOpts{
 				Name:      "connections_active",
@@ -117,6 +166,24 @@ func NewServer(logger *slog.Logger, st state.State) *Server {
 				Subsystem: "server",
 				Help:      "ping latency in ms",
 			}, []string{"source"}),
+			EventAckErrors: promauto.NewCounter(prometheus.CounterOpts{
+				Name:      "event_ack_errors_total",
+				Namespace: "rsca",
+				Subsystem: "server",
+				Help:      "number of check result acknowledgements that failed to send",
+			}),
+			StaleServices: promauto.NewCounter(prometheus.CounterOpts{
+				Name:      "stale_services_total",
+				Namespace: "rsca",
+				Subsystem: "server",
+				Help:      "number of services reported as stale by the freshness check",
+			}),
+			IdentityViolations: promauto.NewCounterVec(prometheus.CounterOpts{
+				Name:      "identity_violations_total",
+				Namespace: "rsca",
+				Subsystem: "server",
+				Help:      "number of hostnames that did not match the client certificate, by decision",
+			}, []string{"decision"}),
 		},
 	}
 }
@@ -169,6 +236,77 @@ func (s *Server) TriggerAll(ctx context.Context, m *api.Members) (*api.TriggerAl
 	}.Build(), nil
 }
 
+// TriggerCheck triggers the named checks on the matching hosts, only checks that are registered as
+// services on a host are sent to it.
+func (s *Server) TriggerCheck(ctx context.Context, in *api.TriggerCheckRequest) (*api.TriggerCheckResponse, error) {
+	out := []*api.TriggeredCheck{}
+
+	for _, streamID := range s.streamIDsFromRecipient(in.GetMembers()) {
+		member, checkNames := s.streamServices(streamID, in.GetChecks())
+		if len(checkNames) == 0 {
+			continue
+		}
+
+		msg := api.Message_builder{
+			Envelope: api.Envelope_builder{
+				Sender: api.Member_builder{
+					Id: proto.String("master"),
+				}.Build(),
+				Recipient: api.RecipientBySender(member),
+			}.Build(),
+			TriggerCheckMessage: api.TriggerCheckMessage_builder{
+				Id:     proto.String(uuid.New().String()),
+				Checks: checkNames,
+			}.Build(),
+		}.Build()
+
+		if err := s.sendToStream(streamID, msg); err != nil {
+			s.Logger.ErrorContext(ctx, "unable to send trigger check message",
+				slog.String("target", member.GetName()), slogtool.ErrorAttr(err),
+			)
+
+			continue
+		}
+
+		for _, checkName := range checkNames {
+			out = append(out, api.TriggeredCheck_builder{
+				Hostname: proto.String(member.GetName()),
+				Check:    proto.String(checkName),
+			}.Build())
+		}
+	}
+
+	return api.TriggerCheckResponse_builder{
+		Checks: out,
+	}.Build(), nil
+}
+
+// streamServices returns the member of a stream and the services it has registered that match
+// the supplied check names.
+func (s *Server) streamServices(streamID string, checkNames []string) (*api.Member, []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.streams[streamID]
+	if !ok || v.Record == nil {
+		return nil, nil
+	}
+
+	out := []string{}
+
+	for _, service := range v.Record.GetService() {
+		for _, checkName := range checkNames {
+			if strings.EqualFold(service, checkName) {
+				out = append(out, service)
+
+				break
+			}
+		}
+	}
+
+	return v.Record, out
+}
+
 func (s *Server) streamIDsToHostnames(streamIDs []string) []string {
 	hostNames := []string{}
 
@@ -208,6 +346,7 @@ func (s *Server) RemoveHost(ctx context.Context, in *api.RemoveHostRequest) (*ap
 			}
 
 			s.Logger.DebugContext(ctx, "host removed from storage", slog.String("target", hostname))
+			s.publishHost(api.WatchEventType_REMOVED, v.GetName(), v)
 
 			out = append(out, v.GetName())
 		}
@@ -243,11 +382,18 @@ func (s *Server) Pipe(stream api.RSCA_PipeServer) error {
 	streamID := uuid.New().String()
 	ctx, cancel := context.WithCancel(context.Background())
 
-	s.lock.Lock()
-	s.streams[streamID] = &serverStream{
+	ss := &serverStream{
 		Stream:       stream,
 		TriggerClose: cancel,
+		acks:         make(chan *api.Message, ackChannelSize),
+	}
+
+	if id, ok := identity.FromContext(stream.Context()); ok {
+		ss.Identity = &id
 	}
+
+	s.lock.Lock()
+	s.streams[streamID] = ss
 	s.lock.Unlock()
 
 	s.metric.ActiveConnections.Inc()
@@ -259,6 +405,12 @@ func (s *Server) Pipe(stream api.RSCA_PipeServer) error {
 
 		s.Logger.DebugContext(ctx, "defer delete stream", slog.String("stream.id", streamID))
 		s.metric.ActiveConnections.Dec()
+
+		if m := s.streams[streamID].Record; m != nil {
+			s.publishHost(api.WatchEventType_DISCONNECT, m.GetName(), m)
+			s.HostInactive(ctx, m.GetName(), "disconnected")
+		}
+
 		delete(s.streams, streamID)
 
 		_ = s.state.DeactivateByStreamID(streamID)
@@ -266,7 +418,7 @@ func (s *Server) Pipe(stream api.RSCA_PipeServer) error {
 
 	msgStream := s.processPipeMessages(ctx, streamID, stream)
 
-	return s.processPipe(ctx, streamID, stream, msgStream)
+	return s.processPipe(ctx, streamID, stream, msgStream, ss.acks)
 }
 
 func (s *Server) processPipeMessages(
@@ -312,9 +464,18 @@ func (s *Server) processPipe(
 	streamID string,
 	stream api.RSCA_PipeServer,
 	msgStream chan serverStreamMessage,
+	acks <-chan *api.Message,
 ) error {
 	for {
 		select {
+		case ack := <-acks:
+			if err := s.sendToStream(streamID, ack); err != nil {
+				s.metric.EventAckErrors.Inc()
+				s.Logger.ErrorContext(ctx, "unable to send EventAckMessage",
+					slog.String("response.id", ack.GetEventAckMessage().GetId()),
+					slogtool.ErrorAttr(err),
+				)
+			}
 		case m, ok := <-msgStream:
 			if ok {
 				if err := m.E; err != nil {
@@ -335,7 +496,7 @@ func (s *Server) processPipe(
 
 				switch v := m.M.WhichMessage(); v { //nolint:exhaustive // default catches unhandled.
 				case api.Message_EventMessage_case:
-					s.processEventMessage(ctx, m.M, m.M.GetEventMessage())
+					s.processEventMessage(ctx, streamID, m.M, m.M.GetEventMessage())
 				case api.Message_RegisterMessage_case:
 					s.processRegisterMessage(ctx, streamID, m.M, m.M.GetRegisterMessage())
 				case api.Message_MemberUpdateMessage_case:
@@ -352,6 +513,8 @@ func (s *Server) processPipe(
 					}
 				case api.Message_PongMessage_case:
 					s.processPongMessage(ctx, streamID, m.M, m.M.GetPongMessage())
+				case api.Message_RunCheckResultMessage_case:
+					s.processRunCheckResultMessage(ctx, streamID, m.M, m.M.GetRunCheckResultMessage())
 				default:
 					s.metric.Received.WithLabelValues("_all", "Unknown").Inc()
 					s.metric.Received.WithLabelValues(m.M.GetEnvelope().GetSender().GetName(), "Unknown").Inc()
@@ -387,26 +550,180 @@ func (s *Server) updateLastSeen(
 
 func (s *Server) processEventMessage(
 	ctx context.Context,
+	streamID string,
 	in *api.Message,
 	msg *api.EventMessage,
 ) {
 	s.metric.Received.WithLabelValues("_all", "EventMessage").Inc()
 	s.metric.Received.WithLabelValues(in.GetEnvelope().GetSender().GetName(), "EventMessage").Inc()
+	s.Logger.DebugContext(ctx, "Received EventMessage")
+
+	hostname, ok := s.enforceIdentity(ctx, streamID, "event.hostname", msg.GetHostname())
+	if !ok {
+		// acknowledge the rejected result so the client does not resend it.
+		s.sendEventAck(ctx, streamID, in, msg)
+
+		return
+	}
+
+	if hostname != msg.GetHostname() {
+		msg.SetHostname(hostname)
+	}
+
+	// the result is acknowledged once the sinks have written it, so a result that is lost before it
+	// reaches nagios is resent by the client.
+	s.logEvent(ctx, in.GetEnvelope().GetSender().GetName(), msg)
+	s.sinks.WriteAsync(ctx, msg, s.storeResult(ctx, msg), func(err error) {
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "unable to write check response", slogtool.ErrorAttr(err))
+
+			return
+		}
+
+		s.queueEventAck(ctx, streamID, in, msg)
+	})
+}
+
+// HandleEvent processes a check result received from source, it is used for results received over
+// the client streams as well as other listeners. The result is written to each of the result sinks.
+func (s *Server) HandleEvent(ctx context.Context, source string, msg *api.EventMessage) error {
+	s.logEvent(ctx, source, msg)
+
+	return s.writeResult(ctx, msg)
+}
+
+// logEvent records the metrics and logs a check result received from source.
+func (s *Server) logEvent(ctx context.Context, source string, msg *api.EventMessage) {
 	s.metric.EventStatus.WithLabelValues(
-		in.GetEnvelope().GetSender().GetName(),
+		source,
 		msg.GetCheck(),
 		msg.GetStatus().String(),
 	).Inc()
-	s.Logger.DebugContext(ctx, "Received EventMessage")
 	s.Logger.InfoContext(ctx, "received check data", slog.String("response.id", msg.GetId()),
-		slog.String("source.hostname", in.GetEnvelope().GetSender().GetName()),
+		slog.String("source.hostname", source),
 		slog.String("check.name", msg.GetCheck()),
 		slog.String("check.status", msg.GetStatus().String()),
 		slog.String("check.output", msg.GetOutput()))
 
-	if err := writeCheckResponse(ctx, s.Logger, msg); err != nil {
-		s.Logger.ErrorContext(ctx, "unable to write check response", slogtool.ErrorAttr(err))
+	if msg.GetType() == api.CheckType_SERVICE {
+		s.freshness.Seen(msg.GetHostname(), msg.GetCheck(), time.Now())
 	}
+
+	if msg.IsSoftState() {
+		s.Logger.DebugContext(ctx, "check is in a soft state",
+			slog.String("response.id", msg.GetId()),
+			slog.String("check.name", msg.GetCheck()),
+			slog.Int("check.retries", int(msg.GetRetries())),
+			slog.Int("check.max-retries", int(msg.GetMaxRetries())),
+		)
+	}
+}
+
+// writeResult stores the check result as the latest result of the host and check, and writes it to
+// the result sinks, the sink filters are matched against the tags of the registered host.
+func (s *Server) writeResult(ctx context.Context, msg *api.EventMessage) error {
+	return s.sinks.Write(ctx, msg, s.storeResult(ctx, msg))
+}
+
+// storeResult stores the check result as the latest result of the host and check and publishes it
+// to the watchers, the tags of the registered host are returned.
+func (s *Server) storeResult(ctx context.Context, msg *api.EventMessage) []string {
+	if err := s.state.SetResult(msg); err != nil {
+		s.Logger.ErrorContext(ctx, "unable to store check result",
+			slog.String("response.id", msg.GetId()), slogtool.ErrorAttr(err),
+		)
+	}
+
+	s.addHistory(ctx, msg)
+
+	var tags []string
+	if member, ok := s.state.GetMemberByHostname(msg.GetHostname()); ok {
+		tags = member.GetTag()
+	}
+
+	s.publishResult(msg, tags)
+
+	return tags
+}
+
+// eventAck returns the acknowledgement of an EventMessage.
+func eventAck(in *api.Message, msg *api.EventMessage) *api.Message {
+	return api.Message_builder{
+		Envelope: api.Envelope_builder{
+			Sender:    api.Member_builder{Id: proto.String("master")}.Build(),
+			Recipient: api.RecipientBySender(in.GetEnvelope().GetSender()),
+		}.Build(),
+		EventAckMessage: api.EventAckMessage_builder{
+			Id: proto.String(msg.GetId()),
+		}.Build(),
+	}.Build()
+}
+
+// queueEventAck queues the acknowledgement of an EventMessage that has been written out, it is sent
+// by the routine processing the client stream.
+func (s *Server) queueEventAck(
+	ctx context.Context,
+	streamID string,
+	in *api.Message,
+	msg *api.EventMessage,
+) {
+	if msg.GetId() == "" {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.streams[streamID]
+	if !ok {
+		return
+	}
+
+	select {
+	case v.acks <- eventAck(in, msg):
+	default:
+		s.metric.EventAckErrors.Inc()
+		s.Logger.WarnContext(ctx, "acknowledgement queue is full, dropping EventAckMessage",
+			slog.String("response.id", msg.GetId()),
+		)
+	}
+}
+
+// sendEventAck acknowledges to the client that an EventMessage has been written out.
+func (s *Server) sendEventAck(
+	ctx context.Context,
+	streamID string,
+	in *api.Message,
+	msg *api.EventMessage,
+) {
+	if msg.GetId() == "" {
+		return
+	}
+
+	if err := s.sendToStream(streamID, eventAck(in, msg)); err != nil {
+		s.metric.EventAckErrors.Inc()
+		s.Logger.ErrorContext(ctx, "unable to send EventAckMessage",
+			slog.String("response.id", msg.GetId()),
+			slogtool.ErrorAttr(err),
+		)
+	}
+}
+
+// sendToStream sends a message to a single client stream.
+func (s *Server) sendToStream(streamID string, msg *api.Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.streams[streamID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrStreamNotFound, streamID)
+	}
+
+	if err := v.Stream.Send(msg); err != nil {
+		return fmt.Errorf("unable to send message to client stream: %w", err)
+	}
+
+	return nil
 }
 
 func (s *Server) processRegisterMessage(
@@ -423,7 +740,21 @@ func (s *Server) processRegisterMessage(
 		slog.Any("rsca.client.capabilities", msg.GetMember().GetCapability()),
 		slog.Any("rsca.client.services", msg.GetMember().GetService()),
 	)
+
+	name, ok := s.enforceIdentity(ctx, streamID, "member.name", msg.GetMember().GetName())
+	if !ok {
+		s.closeStream(streamID)
+
+		return
+	}
+
+	if name != msg.GetMember().GetName() {
+		msg.GetMember().SetName(name)
+	}
+
 	s.updateMember(ctx, streamID, msg.GetMember())
+	s.publishHost(api.WatchEventType_REGISTER, msg.GetMember().GetName(), msg.GetMember())
+	s.hostActive(ctx, msg.GetMember().GetName(), true)
 }
 
 func (s *Server) processMemberUpdateMessage(
@@ -440,7 +771,19 @@ func (s *Server) processMemberUpdateMessage(
 		slog.Any("rsca.client.capabilities", msg.GetMember().GetCapability()),
 		slog.Any("rsca.client.services", msg.GetMember().GetService()),
 	)
+
+	name, ok := s.enforceIdentity(ctx, streamID, "member.name", msg.GetMember().GetName())
+	if !ok {
+		return
+	}
+
+	if name != msg.GetMember().GetName() {
+		msg.GetMember().SetName(name)
+	}
+
 	s.updateMember(ctx, streamID, msg.GetMember())
+	s.publishHost(api.WatchEventType_UPDATE, msg.GetMember().GetName(), msg.GetMember())
+	s.hostActive(ctx, msg.GetMember().GetName(), false)
 }
 
 func (s *Server) updateMember(ctx context.Context, streamID string, m *api.Member) {
@@ -557,7 +900,8 @@ func (s *Server) streamIDsFromRecipient(in *api.Members) []string {
 			streamIDs[streamID] = struct{}{}
 		}
 
-		if stream.Record.GetTag() != nil || s.compareSlices(append(stream.Record.GetTag(), "_all"), in.GetTag()) {
+		// every host has the `_all` tag, the tags are cloned so the member record is not modified.
+		if s.compareSlices(append(slices.Clone(stream.Record.GetTag()), "_all"), in.GetTag()) {
 			streamIDs[streamID] = struct{}{}
 		}
 
@@ -0,0 +1,126 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/sink"
+	"github.com/na4ma4/rsca/internal/state"
+	"github.com/na4ma4/rsca/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// testHost is a client registered with the test server.
+type testHost struct {
+	name     string
+	tags     []string
+	services []string
+}
+
+// startServer starts a server with the hosts registered and returns an admin client.
+func startServer(ctx context.Context, t *testing.T, hosts ...testHost) api.AdminClient {
+	t.Helper()
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	st, err := state.NewDiskState(logger, filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("state.NewDiskState(): error, got '%s', want 'nil'", err)
+	}
+
+	sapi := server.NewServer(logger, st, sink.NewChain(logger))
+	gs := grpc.NewServer()
+	api.RegisterRSCAServer(gs, sapi)
+	api.RegisterAdminServer(gs, sapi)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = gs.Serve(lis) }()
+
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient(): error, got '%s', want 'nil'", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	for _, host := range hosts {
+		stream, streamErr := api.NewRSCAClient(conn).Pipe(ctx)
+		if streamErr != nil {
+			t.Fatalf("RSCAClient.Pipe(): error, got '%s', want 'nil'", streamErr)
+		}
+
+		member := api.Member_builder{
+			Id:      proto.String(host.name),
+			Name:    proto.String(host.name),
+			Tag:     host.tags,
+			Service: host.services,
+		}.Build()
+
+		if err = stream.Send(api.Message_builder{
+			Envelope:        api.Envelope_builder{Sender: member, Recipient: api.MembersByID("_server")}.Build(),
+			RegisterMessage: api.RegisterMessage_builder{Member: member}.Build(),
+		}.Build()); err != nil {
+			t.Fatalf("RSCA_PipeClient.Send(): error, got '%s', want 'nil'", err)
+		}
+	}
+
+	admin := api.NewAdminClient(conn)
+
+	// wait for the registrations to be processed, every host has the `_all` tag.
+	for range 100 {
+		resp, triggerErr := admin.TriggerAll(ctx, api.Members_builder{Tag: []string{"_all"}}.Build())
+		if triggerErr == nil && len(resp.GetNames()) == len(hosts) {
+			return admin
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("waiting for '%d' hosts to register", len(hosts))
+
+	return nil
+}
+
+func TestTriggerCheckByTag(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	admin := startServer(ctx, t,
+		testHost{name: "web01", tags: []string{"web"}, services: []string{"DISK", "HTTP"}},
+		testHost{name: "db01", tags: []string{"db"}, services: []string{"DISK"}},
+	)
+
+	resp, err := admin.TriggerCheck(ctx, api.TriggerCheckRequest_builder{
+		Members: api.Members_builder{Tag: []string{"web"}}.Build(),
+		Checks:  []string{"DISK"},
+	}.Build())
+	if err != nil {
+		t.Fatalf("AdminClient.TriggerCheck(): error, got '%s', want 'nil'", err)
+	}
+
+	got := []string{}
+	for _, v := range resp.GetChecks() {
+		got = append(got, v.GetHostname()+"/"+v.GetCheck())
+	}
+
+	if diff := cmp.Diff([]string{"web01/DISK"}, got); diff != "" {
+		t.Errorf("AdminClient.TriggerCheck(): checks -want +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"time"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetAuditLog enables listing the audit log of administrative requests with ListAudit.
+func (s *Server) SetAuditLog(l *audit.Log) {
+	s.audit = l
+}
+
+// ListAudit streams the most recent entries of the audit log that match the request, oldest first.
+func (s *Server) ListAudit(in *api.ListAuditRequest, stream api.Admin_ListAuditServer) error {
+	if s.audit == nil {
+		return status.Error(codes.Unavailable, "audit log is not enabled")
+	}
+
+	var since time.Time
+	if in.HasSince() {
+		since = in.GetSince().AsTime()
+	}
+
+	entries, err := s.audit.Read(since, int(in.GetLimit()))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, entry := range entries {
+		if err = stream.Send(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
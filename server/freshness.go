@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/freshness"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrInvalidFreshnessStatus is returned when `freshness.status` is not a check status.
+var ErrInvalidFreshnessStatus = errors.New("invalid freshness status")
+
+// RunFreshness is a routine that reports services that have not sent a result for
+// `freshness.periods` check periods, the result is written to the result sinks with the
+// `freshness.status`.
+func (s *Server) RunFreshness(ctx context.Context, cfg config.Conf) func() error {
+	return func() error {
+		v, ok := api.Status_value[strings.ToUpper(cfg.GetString("freshness.status"))]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidFreshnessStatus, cfg.GetString("freshness.status"))
+		}
+
+		status := api.Status(v)
+		periods := cfg.GetInt("freshness.periods")
+		ticker := time.NewTicker(cfg.GetDuration("freshness.tick"))
+
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case t := <-ticker.C:
+				s.checkFreshness(ctx, t, periods, status)
+			}
+		}
+	}
+}
+
+// checkFreshness updates the tracked services from the registered hosts and writes a result for
+// each service that has become stale.
+func (s *Server) checkFreshness(ctx context.Context, now time.Time, periods int, status api.Status) {
+	hostnames := []string{}
+
+	if err := s.state.Walk(func(member *api.Member) error {
+		hostnames = append(hostnames, member.GetName())
+
+		servicePeriods := map[string]time.Duration{}
+		for name, period := range member.GetServicePeriod() {
+			servicePeriods[name] = period.AsDuration()
+		}
+
+		s.freshness.SetPeriods(member.GetName(), servicePeriods, now)
+
+		return nil
+	}); err != nil {
+		s.Logger.ErrorContext(ctx, "unable to walk state for freshness check", slogtool.ErrorAttr(err))
+
+		return
+	}
+
+	s.freshness.Retain(hostnames)
+
+	for _, svc := range s.freshness.Stale(now, periods) {
+		s.metric.StaleServices.Inc()
+		s.Logger.WarnContext(ctx, "service has stopped reporting",
+			slog.String("check.hostname", svc.Hostname),
+			slog.String("check.name", svc.Name),
+			slog.Time("check.last-result", svc.LastResult),
+		)
+
+		msg := staleResult(svc, now, periods, status)
+		if err := s.writeResult(ctx, msg); err != nil {
+			s.Logger.ErrorContext(ctx, "unable to write stale service result",
+				slog.String("check.hostname", svc.Hostname),
+				slog.String("check.name", svc.Name),
+				slogtool.ErrorAttr(err),
+			)
+		}
+	}
+}
+
+// staleResult returns the check result that is written for a stale service.
+func staleResult(svc freshness.Service, now time.Time, periods int, status api.Status) *api.EventMessage {
+	return api.EventMessage_builder{
+		Id:       proto.String(uuid.New().String()),
+		Hostname: proto.String(svc.Hostname),
+		Type:     api.CheckType_SERVICE.Enum(),
+		Check:    proto.String(svc.Name),
+		Status:   &status,
+		Output: proto.String(fmt.Sprintf("no result received for %d periods (last result %s ago)",
+			periods, now.Sub(svc.LastResult).Truncate(time.Second),
+		)),
+		RequestTimestamp: timestamppb.New(now),
+	}.Build()
+}
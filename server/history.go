@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resultHistory is the retention of the stored check result history.
+type resultHistory struct {
+	lock     sync.Mutex
+	enabled  bool
+	maxCount int
+}
+
+// GetResultHistory returns the stored check results of a host and check in the requested time range.
+func (s *Server) GetResultHistory(_ context.Context, in *api.ResultHistoryRequest) (*api.ResultHistoryResponse, error) {
+	if in.GetHostname() == "" || in.GetCheck() == "" {
+		return nil, status.Error(codes.InvalidArgument, "hostname and check are required")
+	}
+
+	var since, until time.Time
+
+	if in.HasSince() {
+		since = in.GetSince().AsTime()
+	}
+
+	if in.HasUntil() {
+		until = in.GetUntil().AsTime()
+	}
+
+	events, err := s.state.GetHistory(in.GetHostname(), in.GetCheck(), since, until)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return api.ResultHistoryResponse_builder{Events: events}.Build(), nil
+}
+
+// addHistory stores the check result in the result history when it is enabled.
+func (s *Server) addHistory(ctx context.Context, msg *api.EventMessage) {
+	s.history.lock.Lock()
+	enabled, maxCount := s.history.enabled, s.history.maxCount
+	s.history.lock.Unlock()
+
+	if !enabled {
+		return
+	}
+
+	if err := s.state.AddHistory(msg, maxCount); err != nil {
+		s.Logger.ErrorContext(ctx, "unable to store check result history",
+			slog.String("response.id", msg.GetId()), slogtool.ErrorAttr(err),
+		)
+	}
+}
+
+// RunHistory is a routine that enables storing the check result history, keeping at most
+// `history.max-count` results of each host and check and removing results older than
+// `history.max-age` every `history.prune-interval`.
+func (s *Server) RunHistory(ctx context.Context, cfg config.Conf) func() error {
+	return func() error {
+		s.history.lock.Lock()
+		s.history.enabled = true
+		s.history.maxCount = cfg.GetInt("history.max-count")
+		s.history.lock.Unlock()
+
+		maxAge := cfg.GetDuration("history.max-age")
+		if maxAge <= 0 {
+			<-ctx.Done()
+
+			return nil
+		}
+
+		s.pruneHistory(ctx, time.Now().Add(-maxAge))
+
+		ticker := time.NewTicker(cfg.GetDuration("history.prune-interval"))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case t := <-ticker.C:
+				s.pruneHistory(ctx, t.Add(-maxAge))
+			}
+		}
+	}
+}
+
+// pruneHistory removes the check results run before the supplied time from the result history.
+func (s *Server) pruneHistory(ctx context.Context, before time.Time) {
+	n, err := s.state.PruneHistory(before)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "unable to prune check result history", slogtool.ErrorAttr(err))
+
+		return
+	}
+
+	if n > 0 {
+		s.Logger.DebugContext(ctx, "pruned check result history",
+			slog.Int("history.count", n), slog.Time("history.before", before),
+		)
+	}
+}
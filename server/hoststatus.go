@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/na4ma4/config"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Host check results use the return code as the host state (0 = UP, 1 = DOWN, 2 = UNREACHABLE).
+const (
+	hostStateUp          = api.Status_OK
+	hostStateDown        = api.Status_WARNING
+	hostStateUnreachable = api.Status_CRITICAL
+)
+
+// pendingDown is a host that will be reported as down if it does not become active again.
+type pendingDown struct {
+	hostname string
+	reason   string
+	since    time.Time
+}
+
+// hostStatus tracks the hosts that have been reported as down because the agent disconnected.
+type hostStatus struct {
+	lock    sync.Mutex
+	enabled bool
+	grace   time.Duration
+	pending map[string]pendingDown
+	down    map[string]bool
+	seen    map[string]bool
+}
+
+func newHostStatus() *hostStatus {
+	return &hostStatus{
+		pending: map[string]pendingDown{},
+		down:    map[string]bool{},
+		seen:    map[string]bool{},
+	}
+}
+
+// HostInactive schedules the host to be reported as down after the `host-status.grace-period`, it
+// is called when the agent disconnects or is deactivated for inactivity.
+func (s *Server) HostInactive(ctx context.Context, hostname, reason string) {
+	if hostname == "" {
+		return
+	}
+
+	s.hostStatus.lock.Lock()
+	defer s.hostStatus.lock.Unlock()
+
+	key := strings.ToLower(hostname)
+	if _, ok := s.hostStatus.pending[key]; ok || !s.hostStatus.enabled || s.hostStatus.down[key] {
+		return
+	}
+
+	s.Logger.DebugContext(ctx, "host inactive, scheduling host down result",
+		slog.String("check.hostname", hostname), slog.String("reason", reason),
+	)
+
+	s.hostStatus.pending[key] = pendingDown{
+		hostname: hostname,
+		reason:   reason,
+		since:    time.Now(),
+	}
+}
+
+// hostActive cancels a pending down result for the host, an up result is written when the host
+// had been reported as down or it registers for the first time since the server started.
+func (s *Server) hostActive(ctx context.Context, hostname string, registered bool) {
+	s.hostStatus.lock.Lock()
+
+	key := strings.ToLower(hostname)
+	delete(s.hostStatus.pending, key)
+
+	sendUp := s.hostStatus.enabled && (s.hostStatus.down[key] || (registered && !s.hostStatus.seen[key]))
+
+	if registered {
+		s.hostStatus.seen[key] = true
+	}
+
+	delete(s.hostStatus.down, key)
+	s.hostStatus.lock.Unlock()
+
+	if sendUp {
+		s.writeHostStatus(ctx, hostname, hostStateUp, "rsca agent connected")
+	}
+}
+
+// RunHostStatus is a routine that writes a host down result for hosts that have been inactive for
+// the `host-status.grace-period`, and enables writing host up results when they return.
+func (s *Server) RunHostStatus(ctx context.Context, cfg config.Conf) func() error {
+	return func() error {
+		state := hostStateDown
+		if strings.EqualFold(cfg.GetString("host-status.state"), "unreachable") {
+			state = hostStateUnreachable
+		}
+
+		s.hostStatus.lock.Lock()
+		s.hostStatus.enabled = true
+		s.hostStatus.grace = cfg.GetDuration("host-status.grace-period")
+		s.hostStatus.lock.Unlock()
+
+		ticker := time.NewTicker(cfg.GetDuration("host-status.tick"))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case t := <-ticker.C:
+				for _, p := range s.hostStatus.expired(t) {
+					s.writeHostStatus(ctx, p.hostname, state,
+						fmt.Sprintf("rsca agent %s at %s", p.reason, p.since.Format(time.RFC3339)),
+					)
+				}
+			}
+		}
+	}
+}
+
+// expired removes and returns the pending hosts whose grace period has passed, they are marked as down.
+func (h *hostStatus) expired(now time.Time) []pendingDown {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	out := []pendingDown{}
+
+	for key, p := range h.pending {
+		if now.Before(p.since.Add(h.grace)) {
+			continue
+		}
+
+		delete(h.pending, key)
+		h.down[key] = true
+		out = append(out, p)
+	}
+
+	return out
+}
+
+// writeHostStatus writes a host check result to the result sinks.
+func (s *Server) writeHostStatus(ctx context.Context, hostname string, state api.Status, output string) {
+	s.Logger.InfoContext(ctx, "writing host status result",
+		slog.String("check.hostname", hostname),
+		slog.Int("check.status", int(state)),
+		slog.String("check.output", output),
+	)
+
+	msg := api.EventMessage_builder{
+		Id:               proto.String(uuid.New().String()),
+		Hostname:         proto.String(hostname),
+		Type:             api.CheckType_HOST.Enum(),
+		Status:           &state,
+		Output:           proto.String(output),
+		RequestTimestamp: timestamppb.Now(),
+	}.Build()
+
+	if err := s.writeResult(ctx, msg); err != nil {
+		s.Logger.ErrorContext(ctx, "unable to write host status result",
+			slog.String("check.hostname", hostname), slogtool.ErrorAttr(err),
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/na4ma4/rsca/internal/identity"
+)
+
+// SetIdentityPolicy enables binding the hostnames used by clients to their certificate identity,
+// it must be called before the server starts serving.
+func (s *Server) SetIdentityPolicy(p *identity.Policy) {
+	s.identity = p
+}
+
+// enforceIdentity applies the identity policy to a hostname received on the stream, it returns the
+// hostname to use and false if the hostname is rejected. Violations are audit logged.
+func (s *Server) enforceIdentity(ctx context.Context, streamID, field, hostname string) (string, bool) {
+	if s.identity == nil {
+		return hostname, true
+	}
+
+	s.lock.Lock()
+	var id *identity.Identity
+	if st, ok := s.streams[streamID]; ok {
+		id = st.Identity
+	}
+	s.lock.Unlock()
+
+	result, decision := hostname, identity.Rejected
+	if id != nil {
+		result, decision = s.identity.Enforce(*id, hostname)
+	}
+
+	if decision == identity.Allowed {
+		return result, true
+	}
+
+	s.metric.IdentityViolations.WithLabelValues(decision.String()).Inc()
+
+	attrs := []any{
+		slog.String("audit.event", "identity-violation"),
+		slog.String("stream.id", streamID),
+		slog.String("identity.field", field),
+		slog.String("identity.hostname", hostname),
+		slog.String("identity.decision", decision.String()),
+	}
+
+	if id != nil {
+		attrs = append(attrs, slog.String("identity.certificate", id.String()))
+	}
+
+	if decision == identity.Rewritten {
+		attrs = append(attrs, slog.String("identity.rewritten", result))
+	}
+
+	s.Logger.WarnContext(ctx, "hostname does not match client certificate", attrs...)
+
+	return result, decision == identity.Rewritten
+}
+
+// closeStream closes the stream of a client.
+func (s *Server) closeStream(streamID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if st, ok := s.streams[streamID]; ok && st.TriggerClose != nil {
+		st.TriggerClose()
+	}
+}
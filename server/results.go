@@ -0,0 +1,47 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListResults streams the latest check result of each host and check that matches the request.
+func (s *Server) ListResults(in *api.ListResultsRequest, stream api.Admin_ListResultsServer) error {
+	if err := s.state.WalkResults(func(msg *api.EventMessage) error {
+		if !resultMatches(in, msg) {
+			return nil
+		}
+
+		return stream.Send(msg)
+	}); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// resultMatches returns true if the check result matches the hostname, check and status filters of
+// the request, an empty filter matches all results.
+func resultMatches(in *api.ListResultsRequest, msg *api.EventMessage) bool {
+	if len(in.GetHostnames()) > 0 && !slices.ContainsFunc(in.GetHostnames(), func(v string) bool {
+		return strings.EqualFold(v, msg.GetHostname())
+	}) {
+		return false
+	}
+
+	if len(in.GetChecks()) > 0 && !slices.ContainsFunc(in.GetChecks(), func(v string) bool {
+		return strings.EqualFold(v, msg.GetCheck())
+	}) {
+		return false
+	}
+
+	if len(in.GetStatuses()) > 0 && !slices.Contains(in.GetStatuses(), msg.GetStatus()) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/na4ma4/go-slogtool"
+	"github.com/na4ma4/rsca/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultRunCheckTimeout is how long RunCheck waits for a reply when the request has no deadline.
+const defaultRunCheckTimeout = time.Minute
+
+// runCheckReply is the reply to a RunCheckMessage, code is the status returned when the reply
+// carries an error.
+type runCheckReply struct {
+	msg  *api.RunCheckResultMessage
+	code codes.Code
+}
+
+// RunCheck runs a check on a connected host and waits for the result.
+//
+// The request is sent down the host's stream as an api.RunCheckMessage and the reply is matched
+// to the request by the message id.
+func (s *Server) RunCheck(ctx context.Context, in *api.RunCheckRequest) (*api.RunCheckResponse, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, defaultRunCheckTimeout)
+		defer cancel()
+	}
+
+	member, ok := s.state.GetMemberByHostname(in.GetHostname())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "host not found: %s", in.GetHostname())
+	}
+
+	streamID, ok := s.state.GetStreamIDByMember(member)
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "host not connected: %s", in.GetHostname())
+	}
+
+	id := uuid.New().String()
+	reply := s.addReply(id)
+
+	defer s.removeReply(id)
+
+	msg := api.Message_builder{
+		Envelope: api.Envelope_builder{
+			Sender:    api.Member_builder{Id: proto.String("master")}.Build(),
+			Recipient: api.RecipientBySender(member),
+		}.Build(),
+		RunCheckMessage: api.RunCheckMessage_builder{
+			Id:    proto.String(id),
+			Check: proto.String(in.GetCheck()),
+		}.Build(),
+	}.Build()
+
+	if err := s.sendToStream(streamID, msg); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "unable to send request to host: %s", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case r := <-reply:
+		if r.msg.GetError() != "" {
+			return nil, status.Error(r.code, r.msg.GetError())
+		}
+
+		if in.GetForward() {
+			// an on-demand result is forwarded as a hard state.
+			ev, _ := proto.Clone(r.msg.GetEvent()).(*api.EventMessage)
+			ev.ClearRetries()
+			ev.ClearMaxRetries()
+
+			if err := s.writeResult(ctx, ev); err != nil {
+				s.Logger.ErrorContext(ctx, "unable to write check response", slogtool.ErrorAttr(err))
+
+				return nil, status.Errorf(codes.Internal, "unable to forward check result: %s", err)
+			}
+		}
+
+		return api.RunCheckResponse_builder{
+			Event: r.msg.GetEvent(),
+		}.Build(), nil
+	}
+}
+
+// addReply registers a channel to receive the reply to the request with the supplied id.
+func (s *Server) addReply(id string) <-chan runCheckReply {
+	s.replyLock.Lock()
+	defer s.replyLock.Unlock()
+
+	c := make(chan runCheckReply, 1)
+	s.replies[id] = c
+
+	return c
+}
+
+// removeReply removes the channel waiting for a reply.
+func (s *Server) removeReply(id string) {
+	s.replyLock.Lock()
+	defer s.replyLock.Unlock()
+
+	delete(s.replies, id)
+}
+
+// processRunCheckResultMessage passes the reply to a RunCheckMessage to the waiting request, errors
+// reported by the client (eg. an unknown check) are returned as codes.FailedPrecondition and results
+// rejected by the identity policy as codes.PermissionDenied.
+func (s *Server) processRunCheckResultMessage(
+	ctx context.Context,
+	streamID string,
+	in *api.Message,
+	msg *api.RunCheckResultMessage,
+) {
+	s.metric.Received.WithLabelValues("_all", "RunCheckResultMessage").Inc()
+	s.metric.Received.WithLabelValues(in.GetEnvelope().GetSender().GetName(), "RunCheckResultMessage").Inc()
+
+	reply := runCheckReply{msg: msg, code: codes.FailedPrecondition}
+
+	if msg.HasEvent() {
+		hostname, ok := s.enforceIdentity(ctx, streamID, "event.hostname", msg.GetEvent().GetHostname())
+
+		switch {
+		case !ok:
+			msg.ClearEvent()
+			msg.SetError("check result hostname does not match client certificate")
+
+			reply.code = codes.PermissionDenied
+		case hostname != msg.GetEvent().GetHostname():
+			msg.GetEvent().SetHostname(hostname)
+		}
+	}
+
+	s.replyLock.Lock()
+	defer s.replyLock.Unlock()
+
+	c, ok := s.replies[msg.GetId()]
+	if !ok {
+		s.Logger.WarnContext(ctx, "received reply for unknown request",
+			slog.String("request.id", msg.GetId()),
+			slog.String("source.hostname", in.GetEnvelope().GetSender().GetName()),
+		)
+
+		return
+	}
+
+	select {
+	case c <- reply:
+	default:
+	}
+}
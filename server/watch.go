@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+
+	"github.com/na4ma4/rsca/api"
+	"github.com/na4ma4/rsca/internal/watch"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Watch streams the check results and host lifecycle events that match the request as they happen,
+// events are dropped if the client is not keeping up.
+func (s *Server) Watch(in *api.WatchRequest, stream api.Admin_WatchServer) error {
+	sub := s.watch.Subscribe(watch.FilterFromRequest(in))
+	defer s.watch.Unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev := <-sub.Events():
+			if n := sub.Dropped(); n > 0 {
+				ev = proto.CloneOf(ev)
+				ev.SetDropped(n)
+			}
+
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// HostReaped publishes a reaped event for a host that was deactivated for inactivity and schedules
+// the host to be reported as down.
+func (s *Server) HostReaped(ctx context.Context, hostname, reason string) {
+	m, _ := s.state.GetMemberByHostname(hostname)
+	s.publishHost(api.WatchEventType_REAPED, hostname, m)
+	s.HostInactive(ctx, hostname, reason)
+}
+
+// publishResult publishes a check result from a host with the supplied tags to the watch subscribers.
+func (s *Server) publishResult(msg *api.EventMessage, tags []string) {
+	s.watch.Publish(api.WatchEvent_builder{
+		Type:      api.WatchEventType_RESULT.Enum(),
+		Hostname:  proto.String(msg.GetHostname()),
+		Timestamp: timestamppb.Now(),
+		Event:     msg,
+	}.Build(), tags)
+}
+
+// publishHost publishes a host lifecycle event to the watch subscribers, the member can be nil when
+// the host is not known. The member is copied as the stream record is updated in place.
+func (s *Server) publishHost(eventType api.WatchEventType, hostname string, m *api.Member) {
+	if m != nil {
+		m = proto.CloneOf(m)
+	}
+
+	s.watch.Publish(api.WatchEvent_builder{
+		Type:      &eventType,
+		Hostname:  proto.String(hostname),
+		Timestamp: timestamppb.Now(),
+		Member:    m,
+	}.Build(), m.GetTag())
+}